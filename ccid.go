@@ -1,9 +1,11 @@
 package ccid_go
 
 import (
+	"context"
 	"encoding/binary"
 	p "github.com/Pencroff/ccid_go/pkg"
 	"io"
+	"math"
 	"sync"
 	"time"
 )
@@ -12,58 +14,254 @@ import (
 type CcIdGen interface {
 	// Next generates the next CcId using the current time.
 	Next() (p.CcId, error)
+	// NextContext generates the next CcId using the current time.
+	// When monotonic payload overflows, it waits for the next timestamp instead of moving timestamp ahead of the clock.
+	// Waiting is interrupted by cancellation of 'ctx', ErrWaitExceedsDeadline is returned without waiting
+	// if the wait would exceed the deadline of 'ctx'.
+	NextContext(ctx context.Context) (p.CcId, error)
 	// NextWithTime generates the next CcId using the provided time.
 	// 't' is time.Time for generated CcId
+	// It returns p.TimestampOutOfRangeError if 't' is out of timestamp range, see p.TimestampRangePolicy.
 	NextWithTime(t time.Time) (p.CcId, error)
+	// NextN generates n CcIds using the current time, it's a shortcut for Fill.
+	NextN(n int) ([]p.CcId, error)
+	// Fill generates CcIds into every element of 'dst' using the current time.
+	// Random bytes for the whole batch are read in one call, the monotonic strategy is applied between consecutive CcIds.
+	// On error 'dst' is filled up to the failed CcId.
+	Fill(dst []p.CcId) error
+	// Drift returns how far the last generated timestamp is ahead of the clock, see ClockPolicy.
+	Drift() time.Duration
+	// Close saves the exact state of the generator to its StateStore, it should be called on shutdown.
+	// It does nothing for generators without a store, see WithStateStore.
+	Close() error
 }
 
 // CcIdGenImplementation is an implementation of CcIdGen.
 type CcIdGenImplementation struct {
-	size          byte
-	nilCcId       p.CcId
-	fingerprint   []byte
-	payload       []byte
-	ctor          p.CcIdCtor
-	rndRd         io.Reader
-	strategy      p.CcIdMonotonicStrategy
-	clock         p.Clock
-	lastTimestamp uint32
-	lastPayload   []byte
+	size         byte
+	nilCcId      p.CcId
+	fingerprint  []byte
+	payload      []byte
+	ctor         p.CcIdMsCtor
+	millisecond  bool
+	epoch        time.Time
+	maxTimestamp uint64
+	rangePolicy  p.TimestampRangePolicy
+	rndRd        io.Reader
+	strategy     p.CcIdMonotonicStrategy
+	clock        p.Clock
+	metrics      Metrics
+	clockPolicy  ClockPolicy
+	maxDrift     time.Duration
+	sleep        func(ctx context.Context, d time.Duration) error
+	// high-water mark of the state store, it's saved a lease ahead of generated timestamps
+	store         StateStore
+	lease         uint64
+	highWaterMark uint64
+	hasMark       bool
+	// timestamp of the last clock reading, it's used to detect the clock moving backwards
+	clockTimestamp uint64
+	hasLast        bool
+	lastTimestamp  uint64
+	lastPayload    []byte
+	// reusable random payload of Fill batches
+	batch []byte
 }
 
 func (g *CcIdGenImplementation) Next() (p.CcId, error) {
-	return g.NextWithTime(g.clock.Now())
+	return g.nextOne(context.Background(), g.clock.Now(), g.clockPolicy == ClockBlock)
+}
+
+func (g *CcIdGenImplementation) NextContext(ctx context.Context) (p.CcId, error) {
+	return g.nextOne(ctx, g.clock.Now(), true)
 }
 
 func (g *CcIdGenImplementation) NextWithTime(t time.Time) (p.CcId, error) {
-	timestamp := p.ToAdjustedTimestamp(t)
+	return g.nextOne(nil, t, false)
+}
+
+func (g *CcIdGenImplementation) nextOne(ctx context.Context, t time.Time, waitCarry bool) (p.CcId, error) {
+	var res [1]p.CcId
+	err := generate(g, ctx, t, waitCarry, res[:], g.ctor)
+	if err != nil {
+		return g.nilCcId, err
+	}
+	return res[0], nil
+}
+
+func (g *CcIdGenImplementation) NextN(n int) ([]p.CcId, error) {
+	if n < 0 {
+		n = 0
+	}
+	res := make([]p.CcId, n)
+	err := g.Fill(res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (g *CcIdGenImplementation) Fill(dst []p.CcId) error {
+	return generate(g, context.Background(), g.clock.Now(), g.clockPolicy == ClockBlock, dst, g.ctor)
+}
+
+// generate fills 'dst' with CcIds created by 'ctor' for the time 't', it's shared by CcIdGenImplementation and Gen.
+// The clock is observed once, see observe, 'ctx' is nil for NextWithTime.
+// Random payload of several CcIds is read in one batch, see readBatch.
+func generate[T any](g *CcIdGenImplementation, ctx context.Context, t time.Time, waitCarry bool, dst []T,
+	ctor func(timestamp uint64, fingerprint []byte, payload []byte) (T, error)) error {
+	if len(dst) == 0 {
+		return nil
+	}
+	r, err := g.observe(ctx, t, waitCarry)
+	if err != nil {
+		return err
+	}
+	var random *[]byte
+	if len(dst) > 1 {
+		batch, err := g.readBatch(len(dst))
+		if err != nil {
+			return err
+		}
+		random = &batch
+	}
+	for i := range dst {
+		timestamp, err := g.advance(&r, random)
+		if err != nil {
+			return err
+		}
+		dst[i], err = ctor(timestamp, g.fingerprint, g.payload)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readBatch reads random payload for a batch of 'n' CcIds into the reusable batch buffer.
+// Monotonic generator needs random payload only for the first CcId and after carry.
+func (g *CcIdGenImplementation) readBatch(n int) ([]byte, error) {
+	if g.strategy != nil {
+		n = 1
+	}
+	size := n * len(g.payload)
+	if cap(g.batch) < size {
+		g.batch = make([]byte, size)
+	}
+	random := g.batch[:size]
+	_, err := g.rndRd.Read(random)
+	if err != nil {
+		g.metrics.ReaderError()
+		return nil, err
+	}
+	return random, nil
+}
+
+// advance moves the generator to the next CcId for the clock reading 'r', see observe.
+// It returns the timestamp of the CcId, the payload is left in g.payload.
+// Random payload is taken from the head of 'random' batch, it's read from rndRd if the batch is nil or exhausted.
+func (g *CcIdGenImplementation) advance(r *clockReading, random *[]byte) (uint64, error) {
+	var err error
 	var carry byte
-	if g.strategy != nil && timestamp <= g.lastTimestamp {
+	timestamp := r.timestamp
+	if g.strategy != nil && !g.isAfterLast(timestamp) {
 		timestamp = g.lastTimestamp
 		g.payload, carry = g.strategy.Mutate(g.lastPayload)
+		g.metrics.Mutated()
 		if carry > 0 {
-			timestamp += 1
-			_, err := g.rndRd.Read(g.payload)
+			g.metrics.Carried()
+			timestamp, err = g.nextTimestamp(r, timestamp)
 			if err != nil {
-				return g.nilCcId, err
+				return 0, err
 			}
+			err = g.readPayload(random)
 		}
 	} else {
-		_, err := g.rndRd.Read(g.payload)
-		if err != nil {
-			return g.nilCcId, err
-		}
+		err = g.readPayload(random)
+	}
+	if err != nil {
+		return 0, err
 	}
+	err = g.checkDrift(r, timestamp)
+	if err != nil {
+		return 0, err
+	}
+	err = g.reserve(timestamp)
+	if err != nil {
+		return 0, err
+	}
+	g.hasLast = true
 	g.lastTimestamp = timestamp
 	copy(g.lastPayload, g.payload[:])
-	return g.ctor(timestamp, g.fingerprint, g.payload)
+	g.metrics.Generated()
+	if g.isAfter(timestamp, r.timestamp) {
+		g.metrics.Drift(g.duration(timestamp, r.timestamp))
+	} else {
+		g.metrics.Drift(0)
+	}
+	return timestamp, nil
+}
+
+func (g *CcIdGenImplementation) readPayload(random *[]byte) error {
+	if random != nil && len(*random) >= len(g.payload) {
+		copy(g.payload, *random)
+		*random = (*random)[len(g.payload):]
+		return nil
+	}
+	_, err := g.rndRd.Read(g.payload)
+	if err != nil {
+		g.metrics.ReaderError()
+	}
+	return err
+}
+
+// isAfterLast reports whether timestamp is after the last generated one.
+func (g *CcIdGenImplementation) isAfterLast(timestamp uint64) bool {
+	return !g.hasLast || g.isAfter(timestamp, g.lastTimestamp)
+}
+
+func (g *CcIdGenImplementation) adjustTimestamp(t time.Time) (uint64, error) {
+	if g.millisecond {
+		return p.AdjustTimestampMs(t, g.epoch, g.rangePolicy)
+	}
+	ts, err := p.AdjustTimestamp(t, g.epoch, g.rangePolicy)
+	return uint64(ts), err
+}
+
+// nextTimestamp returns timestamp following the last one when monotonic payload overflows.
+// ClockBlock policy and NextContext wait for the clock to reach it, otherwise timestamp moves ahead of the clock.
+// With p.TimestampRangeError policy it fails at the max timestamp, the time of 'r' is reported as out of range.
+func (g *CcIdGenImplementation) nextTimestamp(r *clockReading, timestamp uint64) (uint64, error) {
+	canWrap := timestamp < g.maxTimestamp || g.rangePolicy == p.TimestampRangeWrap
+	if r.waitCarry && r.ctx != nil && canWrap {
+		err := g.waitFor(r, (timestamp+1)&g.maxTimestamp)
+		return r.timestamp, err
+	}
+	if timestamp < g.maxTimestamp {
+		return timestamp + 1, nil
+	}
+	switch g.rangePolicy {
+	case p.TimestampRangeClamp:
+		return timestamp, nil
+	case p.TimestampRangeWrap:
+		return 0, nil
+	}
+	err := p.TimestampOutOfRangeError{Time: r.now}
+	if g.millisecond {
+		err.Min = p.ToStandardizedTimeMsWithEpoch(0, g.epoch)
+		err.Max = p.ToStandardizedTimeMsWithEpoch(g.maxTimestamp, g.epoch)
+	} else {
+		err.Min = p.ToStandardizedTimeWithEpoch(0, g.epoch)
+		err.Max = p.ToStandardizedTimeWithEpoch(uint32(g.maxTimestamp), g.epoch)
+	}
+	return 0, err
 }
 
 // NewCcIdGen creates a new CcId Generator (no fingerprint, no monotonic strategy).
 // 'size' must be the size of the CcId in bytes.
 // 'rndRd' must be a reader for providing random bytes.
 func NewCcIdGen(size byte, rndRd io.Reader) (CcIdGen, error) {
-	return newCcIdGenWithClock(size, nil, rndRd, nil, p.RealClock{})
+	return NewGenerator(WithSize(size), WithReader(rndRd))
 }
 
 // NewCcIdGenWithFingerprint creates a new CcId Generator with fingerprint (no monotonic strategy).
@@ -71,7 +269,7 @@ func NewCcIdGen(size byte, rndRd io.Reader) (CcIdGen, error) {
 // 'fingerprint' must be a byte slice of the correct size for the CcId.
 // 'rndRd' must be a reader for providing random bytes.
 func NewCcIdGenWithFingerprint(size byte, fingerprint []byte, rndRd io.Reader) (CcIdGen, error) {
-	return newCcIdGenWithClock(size, fingerprint, rndRd, nil, p.RealClock{})
+	return NewGenerator(WithSize(size), WithFingerprint(fingerprint), WithReader(rndRd))
 }
 
 // NewMonotonicCcIdGen creates a new CcId Generator with monotonic strategy (no fingerprint).
@@ -79,7 +277,7 @@ func NewCcIdGenWithFingerprint(size byte, fingerprint []byte, rndRd io.Reader) (
 // 'rndRd' must be a reader for providing random bytes.
 // 'strategy' must be a monotonic strategy, to set logic for payload mutation.
 func NewMonotonicCcIdGen(size byte, rndRd io.Reader, strategy p.CcIdMonotonicStrategy) (CcIdGen, error) {
-	return newCcIdGenWithClock(size, nil, rndRd, strategy, p.RealClock{})
+	return NewGenerator(WithSize(size), WithReader(rndRd), WithStrategy(strategy))
 }
 
 // NewMonotonicCcIdGenWithFingerprint creates a new CcId Generator with fingerprint and monotonic strategy.
@@ -88,39 +286,122 @@ func NewMonotonicCcIdGen(size byte, rndRd io.Reader, strategy p.CcIdMonotonicStr
 // 'rndRd' must be a reader for providing random bytes.
 // 'strategy' must be a monotonic strategy, to set logic for payload mutation.
 func NewMonotonicCcIdGenWithFingerprint(size byte, fingerprint []byte, rndRd io.Reader, strategy p.CcIdMonotonicStrategy) (CcIdGen, error) {
-	return newCcIdGenWithClock(size, fingerprint, rndRd, strategy, p.RealClock{})
+	return NewGenerator(WithSize(size), WithFingerprint(fingerprint), WithReader(rndRd), WithStrategy(strategy))
+}
+
+// NewCcIdMsGen creates a new CcId Generator of millisecond CcIds: CcId96Ms, CcId128Ms or CcId160Ms.
+// Monotonic strategy keeps CcIds ordered within the same millisecond.
+// 'size' must be the size of the CcId in bytes, 12, 16 or 20.
+// 'fingerprint' must be a byte slice of the correct size for the CcId or nil.
+// 'rndRd' must be a reader for providing random bytes.
+// 'strategy' must be a monotonic strategy or nil for non-monotonic generator.
+func NewCcIdMsGen(size byte, fingerprint []byte, rndRd io.Reader, strategy p.CcIdMonotonicStrategy) (CcIdGen, error) {
+	return NewGenerator(WithSize(size), WithFingerprint(fingerprint), WithReader(rndRd), WithStrategy(strategy),
+		WithMillisecond())
+}
+
+// NewCcIdGenWithEpoch creates a new CcId Generator with timestamps counted from a custom epoch.
+// Warning: CcIds don't keep the epoch, so their Time(), p.Compare with other sizes and p.TimeRange are wrong
+// for a custom epoch. Use p.TimeWithEpoch, or parse CcIds with FromStringWithEpoch to rebase them to p.DefaultEpoch.
+// 'size' must be the size of the CcId in bytes.
+// 'fingerprint' must be a byte slice of the correct size for the CcId or nil.
+// 'rndRd' must be a reader for providing random bytes.
+// 'strategy' must be a monotonic strategy or nil for non-monotonic generator.
+// 'epoch' is the start of timestamps, e.g. p.UnixEpoch. Zero value means p.DefaultEpoch.
+func NewCcIdGenWithEpoch(size byte, fingerprint []byte, rndRd io.Reader, strategy p.CcIdMonotonicStrategy, epoch time.Time) (CcIdGen, error) {
+	return NewGenerator(WithSize(size), WithFingerprint(fingerprint), WithReader(rndRd), WithStrategy(strategy),
+		WithEpoch(epoch))
+}
+
+// NewCcIdGenWithRangePolicy creates a new CcId Generator with a policy for time out of 32 bits timestamp range.
+// The range starts at 2014-05-13T16:53:20Z and ends at 2150-06-19T23:21:35Z, p.TimestampRangeError is used by default.
+// 'size' must be the size of the CcId in bytes.
+// 'fingerprint' must be a byte slice of the correct size for the CcId or nil.
+// 'rndRd' must be a reader for providing random bytes.
+// 'strategy' must be a monotonic strategy or nil for non-monotonic generator.
+// 'policy' must be p.TimestampRangeError, p.TimestampRangeClamp or p.TimestampRangeWrap.
+func NewCcIdGenWithRangePolicy(size byte, fingerprint []byte, rndRd io.Reader, strategy p.CcIdMonotonicStrategy, policy p.TimestampRangePolicy) (CcIdGen, error) {
+	return NewGenerator(WithSize(size), WithFingerprint(fingerprint), WithReader(rndRd), WithStrategy(strategy),
+		WithRangePolicy(policy))
+}
+
+// NewCcIdMsGenWithEpoch is like NewCcIdMsGen but timestamps are counted from a custom epoch.
+// 'epoch' is the start of timestamps, e.g. p.UnixEpoch. Zero value means p.DefaultEpoch.
+func NewCcIdMsGenWithEpoch(size byte, fingerprint []byte, rndRd io.Reader, strategy p.CcIdMonotonicStrategy, epoch time.Time) (CcIdGen, error) {
+	return NewGenerator(WithSize(size), WithFingerprint(fingerprint), WithReader(rndRd), WithStrategy(strategy),
+		WithMillisecond(), WithEpoch(epoch))
 }
 
 func newCcIdGenWithClock(size byte, fingerprint []byte, rndRd io.Reader, s p.CcIdMonotonicStrategy, c p.Clock) (CcIdGen, error) {
-	var ctor p.CcIdCtor
+	return NewGenerator(WithSize(size), WithFingerprint(fingerprint), WithReader(rndRd), WithStrategy(s), WithClock(c))
+}
+
+func newGenerator(cfg generatorConfig) *CcIdGenImplementation {
+	var ctor p.CcIdMsCtor
 	var nilCcId p.CcId
-	switch size {
-	case p.ByteSliceSize64:
-		ctor = p.NewCcId64WithFingerprint
-		nilCcId = p.NilCcId64
-	case p.ByteSliceSize96:
-		ctor = p.NewCcId96WithFingerprint
-		nilCcId = p.NilCcId96
-	case p.ByteSliceSize128:
-		ctor = p.NewCcId128WithFingerprint
-		nilCcId = p.NilCcId128
-	case p.ByteSliceSize160:
-		ctor = p.NewCcId160WithFingerprint
-		nilCcId = p.NilCcId160
+	timestampSize := byte(p.TimestampSize)
+	var maxTimestamp uint64 = math.MaxUint32
+	if cfg.millisecond {
+		timestampSize = p.TimestampMsSize
+		maxTimestamp = p.MaxTimestampMs
+		switch cfg.size {
+		case p.ByteSliceSize96:
+			ctor = p.NewCcId96MsWithFingerprint
+			nilCcId = p.NilCcId96Ms
+		case p.ByteSliceSize128:
+			ctor = p.NewCcId128MsWithFingerprint
+			nilCcId = p.NilCcId128Ms
+		case p.ByteSliceSize160:
+			ctor = p.NewCcId160MsWithFingerprint
+			nilCcId = p.NilCcId160Ms
+		}
+	} else {
+		var secondCtor p.CcIdCtor
+		switch cfg.size {
+		case p.ByteSliceSize64:
+			secondCtor = p.NewCcId64WithFingerprint
+			nilCcId = p.NilCcId64
+		case p.ByteSliceSize96:
+			secondCtor = p.NewCcId96WithFingerprint
+			nilCcId = p.NilCcId96
+		case p.ByteSliceSize128:
+			secondCtor = p.NewCcId128WithFingerprint
+			nilCcId = p.NilCcId128
+		case p.ByteSliceSize160:
+			secondCtor = p.NewCcId160WithFingerprint
+			nilCcId = p.NilCcId160
+		}
+		ctor = func(timestamp uint64, fingerprint []byte, payload []byte) (p.CcId, error) {
+			return secondCtor(uint32(timestamp), fingerprint, payload)
+		}
+	}
+	payloadSize := cfg.size - timestampSize - byte(len(cfg.fingerprint))
+	lease := uint64(cfg.lease / time.Second)
+	if cfg.millisecond {
+		lease = uint64(cfg.lease / time.Millisecond)
 	}
-	payloadSize := size - p.TimestampSize - byte(len(fingerprint))
 	return &CcIdGenImplementation{
-		size:          size,
+		size:          cfg.size,
 		nilCcId:       nilCcId,
-		fingerprint:   fingerprint,
+		fingerprint:   cfg.fingerprint,
 		payload:       make([]byte, payloadSize),
 		ctor:          ctor,
-		rndRd:         rndRd,
-		strategy:      s,
-		clock:         c,
+		millisecond:   cfg.millisecond,
+		epoch:         cfg.epoch,
+		maxTimestamp:  maxTimestamp,
+		rangePolicy:   cfg.rangePolicy,
+		rndRd:         cfg.rndRd,
+		strategy:      cfg.strategy,
+		clock:         cfg.clock,
+		metrics:       cfg.metrics,
+		clockPolicy:   cfg.clockPolicy,
+		maxDrift:      cfg.maxDrift,
+		sleep:         sleepContext(cfg.sleep),
+		store:         cfg.store,
+		lease:         max(lease, 1),
 		lastTimestamp: 0,
 		lastPayload:   make([]byte, payloadSize),
-	}, nil
+	}
 }
 
 type CcIdGenImplementationLocked struct {
@@ -134,12 +415,44 @@ func (g *CcIdGenImplementationLocked) Next() (p.CcId, error) {
 	return g.gen.Next()
 }
 
+// NextContext holds the lock while waiting, see CcIdGen.NextContext.
+func (g *CcIdGenImplementationLocked) NextContext(ctx context.Context) (p.CcId, error) {
+	g.m.Lock()
+	defer g.m.Unlock()
+	return g.gen.NextContext(ctx)
+}
+
 func (g *CcIdGenImplementationLocked) NextWithTime(t time.Time) (p.CcId, error) {
 	g.m.Lock()
 	defer g.m.Unlock()
 	return g.gen.NextWithTime(t)
 }
 
+func (g *CcIdGenImplementationLocked) NextN(n int) ([]p.CcId, error) {
+	g.m.Lock()
+	defer g.m.Unlock()
+	return g.gen.NextN(n)
+}
+
+func (g *CcIdGenImplementationLocked) Fill(dst []p.CcId) error {
+	g.m.Lock()
+	defer g.m.Unlock()
+	return g.gen.Fill(dst)
+}
+
+func (g *CcIdGenImplementationLocked) Drift() time.Duration {
+	g.m.Lock()
+	defer g.m.Unlock()
+	return g.gen.Drift()
+}
+
+// Close closes the wrapped generator, see CcIdGen.Close.
+func (g *CcIdGenImplementationLocked) Close() error {
+	g.m.Lock()
+	defer g.m.Unlock()
+	return g.gen.Close()
+}
+
 // NewCcIdGenLocked wraps a CcIdGen with a mutex. It's useful for making a CcIdGen thread-safe.
 // 'g' must be a CcIdGen.
 func NewCcIdGenLocked(g CcIdGen) CcIdGen {
@@ -197,3 +510,100 @@ func FromBytes(b []byte, fingerprintSize byte) (p.CcId, error) {
 	fingerprintEndIdx := p.TimestampSize + fingerprintSize
 	return c(timestamp, b[p.TimestampSize:fingerprintEndIdx], b[fingerprintEndIdx:])
 }
+
+// FromStringMs creates a millisecond CcId from a string. It requires the fingerprint size and the base of the string.
+// 's' must be a string of the correct size for CcId96Ms, CcId128Ms or CcId160Ms.
+// 'fingerprintSize' must be the size of the fingerprint in bytes.
+// 'base' must be the base of the string. It can be 16, 32 or 62.
+func FromStringMs(s string, fingerprintSize byte, base byte) (p.CcId, error) {
+	b, err := p.DecodeFromBase(s, base)
+	if err != nil {
+		return nil, err
+	}
+	return FromBytesMs(b, fingerprintSize)
+}
+
+// FromBase32Lenient creates a CcId from a Crockford base32 string accepting lowercase, 'O', 'I', 'L' and hyphens,
+// e.g. typed by a user, see p.NormalizeBase32. It requires the fingerprint size.
+// 'fingerprintSize' must be the size of the fingerprint in bytes.
+func FromBase32Lenient(s string, fingerprintSize byte) (p.CcId, error) {
+	b, err := p.DecodeFromBase32Lenient(s)
+	if err != nil {
+		return nil, err
+	}
+	return FromBytes(b, fingerprintSize)
+}
+
+// FromBase32MsLenient is like FromBase32Lenient but creates a millisecond CcId, see FromBytesMs.
+func FromBase32MsLenient(s string, fingerprintSize byte) (p.CcId, error) {
+	b, err := p.DecodeFromBase32Lenient(s)
+	if err != nil {
+		return nil, err
+	}
+	return FromBytesMs(b, fingerprintSize)
+}
+
+// FromBytesMs creates a millisecond CcId from a byte slice. It requires the fingerprint size.
+// 'b' must be a byte slice of the correct size for CcId96Ms, CcId128Ms or CcId160Ms.
+// 'fingerprintSize' must be the size of the fingerprint in bytes, 0 to 5.
+func FromBytesMs(b []byte, fingerprintSize byte) (p.CcId, error) {
+	l := len(b)
+	var c p.CcIdMsCtor
+	switch l {
+	case p.ByteSliceSize96:
+		c = p.NewCcId96MsWithFingerprint
+	case p.ByteSliceSize128:
+		c = p.NewCcId128MsWithFingerprint
+	case p.ByteSliceSize160:
+		c = p.NewCcId160MsWithFingerprint
+	default:
+		return nil, p.InvalidLengthError(byte(l))
+	}
+	if fingerprintSize > p.MaxFingerprintSize {
+		return nil, p.InvalidFingerprintSizeError{
+			ProvidedSize: fingerprintSize,
+			RequiredSize: p.MaxFingerprintSize,
+		}
+	}
+	timestamp := p.Uint48BigEndian(b[:p.TimestampMsSize])
+	fingerprintEndIdx := p.TimestampMsSize + fingerprintSize
+	return c(timestamp, b[p.TimestampMsSize:fingerprintEndIdx], b[fingerprintEndIdx:])
+}
+
+// FromStringWithEpoch is like FromString for CcIds generated WithEpoch option.
+// The timestamp is rebased from 'epoch' to p.DefaultEpoch, so Time(), p.Compare and p.TimeRange work as usual.
+// It returns p.TimestampOutOfRangeError if the time can't be represented on p.DefaultEpoch.
+func FromStringWithEpoch(s string, fingerprintSize byte, base byte, epoch time.Time) (p.CcId, error) {
+	id, err := FromString(s, fingerprintSize, base)
+	if err != nil {
+		return nil, err
+	}
+	return p.Rebase(id, epoch, p.DefaultEpoch)
+}
+
+// FromBytesWithEpoch is like FromBytes for CcIds generated WithEpoch option, see FromStringWithEpoch.
+func FromBytesWithEpoch(b []byte, fingerprintSize byte, epoch time.Time) (p.CcId, error) {
+	id, err := FromBytes(b, fingerprintSize)
+	if err != nil {
+		return nil, err
+	}
+	return p.Rebase(id, epoch, p.DefaultEpoch)
+}
+
+// FromStringMsWithEpoch is like FromStringMs for CcIds generated WithEpoch option, see FromStringWithEpoch.
+func FromStringMsWithEpoch(s string, fingerprintSize byte, base byte, epoch time.Time) (p.CcId, error) {
+	id, err := FromStringMs(s, fingerprintSize, base)
+	if err != nil {
+		return nil, err
+	}
+	return p.Rebase(id, epoch, p.DefaultEpoch)
+}
+
+// FromBytesMsWithEpoch is like FromBytesMs for CcIds generated WithEpoch option, see FromStringWithEpoch.
+func FromBytesMsWithEpoch(b []byte, fingerprintSize byte, epoch time.Time) (p.CcId, error) {
+	id, err := FromBytesMs(b, fingerprintSize)
+	if err != nil {
+		return nil, err
+	}
+	return p.Rebase(id, epoch, p.DefaultEpoch)
+}
@@ -1,7 +1,6 @@
 package ccid_go
 
 import (
-	"bytes"
 	"fmt"
 	e "github.com/Pencroff/ccid_go/extras"
 	p "github.com/Pencroff/ccid_go/pkg"
@@ -44,7 +43,7 @@ func TestCcIdGen_Race(t *testing.T) {
 
 			for i := 0; i < numRoutines*numCycles; i++ {
 				next := <-queue
-				if bytes.Compare(prev.Bytes(), next.Bytes()) >= 0 {
+				if p.Compare(prev, next) >= 0 {
 					fmt.Printf("%d - Not sequential in channel.\nPrev: %x\nmore then\nNext: %x\nDetails:\nPrev: %p - %#v\nNext: %p - %#v\n",
 						i, prev.Bytes(), next.Bytes(), &prev, prev, &next, next)
 				}
@@ -2,6 +2,7 @@ package ccid_go
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	e "github.com/Pencroff/ccid_go/extras"
 	p "github.com/Pencroff/ccid_go/pkg"
@@ -455,3 +456,328 @@ func TestMonotonicRandCcIdGen_LargeCycle(t *testing.T) {
 		})
 	}
 }
+
+func TestCcIdMsGen(t *testing.T) {
+	mockTime := time.Date(2024, 1, 16, 15, 44, 56, 789000000, time.UTC)
+	for _, size := range []byte{p.ByteSliceSize96, p.ByteSliceSize128, p.ByteSliceSize160} {
+		t.Run(fmt.Sprintf("size %d", size), func(t *testing.T) {
+			r := &mockReader{Val: 0xA5}
+			c := &mockStaticClock{Val: mockTime}
+			s := p.NewFiftyPercentMonotonicStrategy(r)
+			gen, err := NewGenerator(WithSize(size), WithFingerprint([]byte{0x55, 0x66}), WithReader(r), WithStrategy(s),
+				WithClock(c), WithMillisecond())
+			if err != nil {
+				t.Fatalf("NewGenerator(%d) error = %v", size, err)
+			}
+			prev, _ := gen.Next()
+			if !prev.Time().Equal(mockTime) {
+				t.Errorf("Time() =\n%s, want\n%s", prev.Time().Format(p.RFC3339Milli), mockTime.Format(p.RFC3339Milli))
+			}
+			if !bytes.Equal(prev.Fingerprint(), []byte{0x55, 0x66}) {
+				t.Errorf("Fingerprint() = %x, want 5566", prev.Fingerprint())
+			}
+			for i := 0; i < 100; i++ {
+				next, _ := gen.Next()
+				if p.Compare(prev, next) >= 0 {
+					t.Errorf("Next() = %#v, want greater than %#v", next, prev)
+				}
+				if next.(interface{ TimestampMs() uint64 }).TimestampMs() != p.ToAdjustedTimestampMs(mockTime) {
+					t.Errorf("Next() = %#v, want timestamp %d", next, p.ToAdjustedTimestampMs(mockTime))
+				}
+				prev = next
+			}
+			next, _ := gen.NextWithTime(mockTime.Add(time.Millisecond))
+			if next.Time().Sub(prev.Time()) != time.Millisecond {
+				t.Errorf("NextWithTime(+1ms) = %#v, want 1ms after %#v", next, prev)
+			}
+		})
+	}
+	t.Run("errors", func(t *testing.T) {
+		var lengthErr p.InvalidLengthError
+		if _, err := NewCcIdMsGen(p.ByteSliceSize64, nil, &mockReader{}, nil); !errors.As(err, &lengthErr) {
+			t.Errorf("NewCcIdMsGen(8) error = %v, want InvalidLengthError", err)
+		}
+		var fpErr p.InvalidFingerprintSizeError
+		if _, err := NewCcIdMsGen(p.ByteSliceSize96, make([]byte, 6), &mockReader{}, nil); !errors.As(err, &fpErr) {
+			t.Errorf("NewCcIdMsGen(12, 6 bytes fingerprint) error = %v, want InvalidFingerprintSizeError", err)
+		}
+	})
+}
+
+func TestFromStringBytesMs(t *testing.T) {
+	groups := map[string]map[string]p.CcIdMsTestCases{
+		"ccid96ms":  p.TestCaseCcId96MsMap,
+		"ccid128ms": p.TestCaseCcId128MsMap,
+		"ccid160ms": p.TestCaseCcId160MsMap,
+	}
+	for _, name := range p.SortKeys(groups) {
+		m := groups[name]
+		for _, key := range p.SortKeys(m) {
+			tc := m[key]
+			fpSize := byte(len(tc.Fingerprint))
+			t.Run(name+"_"+key, func(t *testing.T) {
+				strs := map[byte]string{p.BASE62: tc.Base62, p.BASE32: tc.Base32, p.BASE16: tc.Base16}
+				for base, s := range strs {
+					got, err := FromStringMs(s, fpSize, base)
+					if err != nil {
+						t.Errorf("FromStringMs(%s, %d, %d) error = %v", s, fpSize, base, err)
+						continue
+					}
+					if v := fmt.Sprintf("%#v", got); v != tc.GoString {
+						t.Errorf("FromStringMs(%s, %d, %d) =\n%s, want\n%s", s, fpSize, base, v, tc.GoString)
+					}
+				}
+				got, err := FromBytesMs(tc.Bytes, fpSize)
+				if err != nil || fmt.Sprintf("%#v", got) != tc.GoString {
+					t.Errorf("FromBytesMs(%x, %d) =\n%#v, %v, want\n%s", tc.Bytes, fpSize, got, err, tc.GoString)
+				}
+			})
+		}
+	}
+	var lengthErr p.InvalidLengthError
+	if _, err := FromBytesMs(make([]byte, p.ByteSliceSize64), 0); !errors.As(err, &lengthErr) {
+		t.Errorf("FromBytesMs(8 bytes) error = %v, want InvalidLengthError", err)
+	}
+}
+
+func TestFromBase32Lenient(t *testing.T) {
+	lenient := func(s string) string {
+		s = strings.NewReplacer("0", "o", "1", "l").Replace(strings.ToLower(s))
+		return s[:4] + "-" + s[4:]
+	}
+	tc := p.TestCaseCcId128Map["some id fingerprint"]
+	fpSize := byte(len(tc.Fingerprint))
+	got, err := FromBase32Lenient(lenient(tc.Base32), fpSize)
+	if err != nil || fmt.Sprintf("%#v", got) != tc.GoString {
+		t.Errorf("FromBase32Lenient(%s, %d) =\n%#v, %v, want\n%s", lenient(tc.Base32), fpSize, got, err, tc.GoString)
+	}
+	tcMs := p.TestCaseCcId160MsMap["fingerprint"]
+	fpSize = byte(len(tcMs.Fingerprint))
+	got, err = FromBase32MsLenient(lenient(tcMs.Base32), fpSize)
+	if err != nil || fmt.Sprintf("%#v", got) != tcMs.GoString {
+		t.Errorf("FromBase32MsLenient(%s, %d) =\n%#v, %v, want\n%s", lenient(tcMs.Base32), fpSize, got, err, tcMs.GoString)
+	}
+	if _, err = FromBase32Lenient(tc.Base32+"U", fpSize); !errors.As(err, new(p.InvalidCharacterError)) {
+		t.Errorf("FromBase32Lenient(%sU) error = %v, want InvalidCharacterError", tc.Base32, err)
+	}
+	if _, err = FromBase32MsLenient(strings.Repeat("-", 300), 0); !errors.As(err, new(p.InputTooLongError)) {
+		t.Errorf("FromBase32MsLenient(300 hyphens) error = %v, want InputTooLongError", err)
+	}
+}
+
+func TestCcIdGenWithEpoch(t *testing.T) {
+	mockTime := time.Date(2024, 1, 16, 15, 44, 56, 789000000, time.UTC)
+	epochs := map[string]time.Time{
+		"default": {},
+		"unix":    p.UnixEpoch,
+		"2020":    time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for _, key := range p.SortKeys(epochs) {
+		epoch := epochs[key]
+		t.Run(key, func(t *testing.T) {
+			r := &mockReader{Val: 0xA5}
+			c := &mockStaticClock{Val: mockTime}
+			gen, _ := NewGenerator(WithSize(p.ByteSliceSize96), WithFingerprint([]byte{0x55}), WithReader(r), WithClock(c),
+				WithEpoch(epoch))
+			id, _ := gen.Next()
+			if got := p.TimeWithEpoch(id, epoch); !got.Equal(mockTime.Truncate(time.Second)) {
+				t.Errorf("TimeWithEpoch() = %s, want %s", got, mockTime.Truncate(time.Second))
+			}
+			if id.Timestamp() != p.ToAdjustedTimestampWithEpoch(mockTime, epoch) {
+				t.Errorf("Timestamp() = %d, want %d", id.Timestamp(), p.ToAdjustedTimestampWithEpoch(mockTime, epoch))
+			}
+			got, err := FromString(id.AsBase32(), 1, p.BASE32)
+			if err != nil || got != id {
+				t.Errorf("FromString(%s) = %#v, %v, want %#v", id.AsBase32(), got, err, id)
+			}
+
+			genMs, _ := NewGenerator(WithSize(p.ByteSliceSize128), WithReader(r), WithClock(c), WithEpoch(epoch), WithMillisecond())
+			idMs, _ := genMs.Next()
+			if got := p.TimeWithEpoch(idMs, epoch); !got.Equal(mockTime) {
+				t.Errorf("Ms TimeWithEpoch() = %s, want %s", got, mockTime)
+			}
+			got, err = FromBytesMs(idMs.Bytes(), 0)
+			if err != nil || got != idMs {
+				t.Errorf("FromBytesMs(%x) = %#v, %v, want %#v", idMs.Bytes(), got, err, idMs)
+			}
+
+			got, err = FromStringWithEpoch(id.AsBase32(), 1, p.BASE32, epoch)
+			if err != nil || !got.Time().Equal(mockTime.Truncate(time.Second)) || !p.SliceEqual(got.Payload(), id.Payload()) {
+				t.Errorf("FromStringWithEpoch(%s) = %#v, %v, want time %s", id.AsBase32(), got, err, mockTime)
+			}
+			got, err = FromBytesWithEpoch(id.Bytes(), 1, epoch)
+			if err != nil || !got.Time().Equal(mockTime.Truncate(time.Second)) || !p.SliceEqual(got.Fingerprint(), id.Fingerprint()) {
+				t.Errorf("FromBytesWithEpoch(%x) = %#v, %v, want time %s", id.Bytes(), got, err, mockTime)
+			}
+			got, err = FromStringMsWithEpoch(idMs.AsBase62(), 0, p.BASE62, epoch)
+			if err != nil || !got.Time().Equal(mockTime) {
+				t.Errorf("FromStringMsWithEpoch(%s) = %#v, %v, want time %s", idMs.AsBase62(), got, err, mockTime)
+			}
+			got, err = FromBytesMsWithEpoch(idMs.Bytes(), 0, epoch)
+			if err != nil || !got.Time().Equal(mockTime) || !p.SliceEqual(got.Payload(), idMs.Payload()) {
+				t.Errorf("FromBytesMsWithEpoch(%x) = %#v, %v, want time %s", idMs.Bytes(), got, err, mockTime)
+			}
+		})
+	}
+	t.Run("out of range", func(t *testing.T) {
+		var rangeErr p.TimestampOutOfRangeError
+		if _, err := FromBytesWithEpoch(make([]byte, p.ByteSliceSize96), 0, p.UnixEpoch); !errors.As(err, &rangeErr) {
+			t.Errorf("FromBytesWithEpoch(1970) error = %v, want TimestampOutOfRangeError", err)
+		}
+		if _, err := FromStringMsWithEpoch("0000", 0, p.BASE62, p.UnixEpoch); !errors.As(err, new(p.InvalidLengthError)) {
+			t.Errorf("FromStringMsWithEpoch(0000) error = %v, want InvalidLengthError", err)
+		}
+	})
+}
+
+func TestCcIdGenRangePolicy(t *testing.T) {
+	before := time.Date(2014, 5, 13, 16, 53, 19, 0, time.UTC)
+	last := time.Date(2150, 6, 19, 23, 21, 35, 0, time.UTC)
+	after := last.Add(time.Second)
+	t.Run("error", func(t *testing.T) {
+		gen, _ := NewCcIdGen(p.ByteSliceSize96, &mockReader{})
+		for _, v := range []time.Time{before, after} {
+			var rangeErr p.TimestampOutOfRangeError
+			if _, err := gen.NextWithTime(v); !errors.As(err, &rangeErr) {
+				t.Errorf("NextWithTime(%s) error = %v, want TimestampOutOfRangeError", v, err)
+			}
+		}
+	})
+	t.Run("error monotonic overflow", func(t *testing.T) {
+		r := &mockStaticReader{Val: 0xFF}
+		gen, _ := NewCcIdGenWithRangePolicy(p.ByteSliceSize64, nil, r, p.NewFiftyPercentMonotonicStrategy(r), p.TimestampRangeError)
+		if _, err := gen.NextWithTime(last); err != nil {
+			t.Fatalf("NextWithTime(%s) error = %v", last, err)
+		}
+		var rangeErr p.TimestampOutOfRangeError
+		if _, err := gen.NextWithTime(last); !errors.As(err, &rangeErr) {
+			t.Errorf("NextWithTime(%s) error = %v, want TimestampOutOfRangeError", last, err)
+		}
+	})
+	t.Run("clamp", func(t *testing.T) {
+		gen, _ := NewCcIdGenWithRangePolicy(p.ByteSliceSize96, nil, &mockReader{}, nil, p.TimestampRangeClamp)
+		idA, errA := gen.NextWithTime(before)
+		idB, errB := gen.NextWithTime(after)
+		if errA != nil || errB != nil || idA.Timestamp() != 0 || idB.Timestamp() != 0xFFFFFFFF {
+			t.Errorf("NextWithTime() = %#v, %v and %#v, %v, want min and max timestamps", idA, errA, idB, errB)
+		}
+	})
+	t.Run("wrap monotonic", func(t *testing.T) {
+		r := &mockReader{Val: 0xA5}
+		gen, _ := NewCcIdGenWithRangePolicy(p.ByteSliceSize96, nil, r, p.NewFiftyPercentMonotonicStrategy(r), p.TimestampRangeWrap)
+		idA, _ := gen.NextWithTime(last)
+		idB, err := gen.NextWithTime(after)
+		if err != nil || idA.Timestamp() != 0xFFFFFFFF || idB.Timestamp() != 0 {
+			t.Errorf("NextWithTime() = %#v and %#v, %v, want max timestamp and 0", idA, idB, err)
+		}
+		if !bytes.Equal(idB.Payload(), []byte{0xA5 + 8, 0xA6 + 8, 0xA7 + 8, 0xA8 + 8, 0xA9 + 8, 0xAA + 8, 0xAB + 8, 0xAC + 8}) {
+			t.Errorf("NextWithTime(%s) = %#v, want fresh payload in the next era", after, idB)
+		}
+		idC, _ := gen.NextWithTime(last)
+		if idC.Timestamp() != 0 {
+			t.Errorf("NextWithTime(%s) = %#v, want monotonic timestamp 0 after era change", last, idC)
+		}
+	})
+}
+
+func TestCcIdGenBatch(t *testing.T) {
+	mockTime := time.Date(2024, 1, 16, 15, 44, 56, 0, time.UTC)
+	t.Run("non monotonic", func(t *testing.T) {
+		r := &mockReader{Val: 0xA5}
+		gen, _ := newCcIdGenWithClock(p.ByteSliceSize64, nil, r, nil, &mockStaticClock{Val: mockTime})
+		ids, err := gen.NextN(3)
+		if err != nil || len(ids) != 3 {
+			t.Fatalf("NextN(3) = %v, %v, want 3 CcIds", ids, err)
+		}
+		want := [][]byte{
+			{0x12, 0x34, 0x56, 0x78, 0xA5, 0xA6, 0xA7, 0xA8},
+			{0x12, 0x34, 0x56, 0x78, 0xA9, 0xAA, 0xAB, 0xAC},
+			{0x12, 0x34, 0x56, 0x78, 0xAD, 0xAE, 0xAF, 0xB0},
+		}
+		for i, id := range ids {
+			if !bytes.Equal(id.Bytes(), want[i]) {
+				t.Errorf("NextN(3)[%d] = %x, want %x", i, id.Bytes(), want[i])
+			}
+		}
+	})
+	t.Run("monotonic", func(t *testing.T) {
+		r := &mockStaticReader{Val: 0xA5}
+		s := p.NewIncreaseMonotonicStrategy()
+		gen, _ := newCcIdGenWithClock(p.ByteSliceSize96, []byte{0x55}, r, s, &mockStaticClock{Val: mockTime})
+		first, _ := gen.Next()
+		dst := make([]p.CcId, 1000)
+		if err := NewCcIdGenLocked(gen).Fill(dst); err != nil {
+			t.Fatalf("Fill() error = %v", err)
+		}
+		prev := first
+		for i, id := range dst {
+			if p.Compare(prev, id) >= 0 || id.Timestamp() != first.Timestamp() {
+				t.Errorf("Fill()[%d] = %#v, want greater than %#v in the same second", i, id, prev)
+			}
+			prev = id
+		}
+		want := []byte{0x12, 0x34, 0x56, 0x78, 0x55, 0xA5, 0xA5, 0xA5, 0xA5, 0xA5, 0xA9, 0x8D}
+		if !bytes.Equal(prev.Bytes(), want) {
+			t.Errorf("Fill()[999] = %x, want %x", prev.Bytes(), want)
+		}
+	})
+	t.Run("empty", func(t *testing.T) {
+		gen, _ := NewCcIdGen(p.ByteSliceSize96, &mockReader{})
+		if ids, err := gen.NextN(0); err != nil || len(ids) != 0 {
+			t.Errorf("NextN(0) = %v, %v, want empty slice", ids, err)
+		}
+		if err := gen.Fill(nil); err != nil {
+			t.Errorf("Fill(nil) error = %v", err)
+		}
+	})
+}
+
+const benchmarkBatchSize = 1024
+
+func BenchmarkCcIdGenLocked_Next(b *testing.B) {
+	r, _ := e.NewHybridRandReaderWithSize(e.SIZE_32k)
+	gen, _ := NewMonotonicCcIdGen(p.ByteSliceSize128, r, p.NewFiftyPercentMonotonicStrategy(r))
+	lockedGen := NewCcIdGenLocked(gen)
+	dst := make([]p.CcId, benchmarkBatchSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := range dst {
+			dst[j], _ = lockedGen.Next()
+		}
+	}
+}
+
+func BenchmarkCcIdGenLocked_Fill(b *testing.B) {
+	r, _ := e.NewHybridRandReaderWithSize(e.SIZE_32k)
+	gen, _ := NewMonotonicCcIdGen(p.ByteSliceSize128, r, p.NewFiftyPercentMonotonicStrategy(r))
+	lockedGen := NewCcIdGenLocked(gen)
+	dst := make([]p.CcId, benchmarkBatchSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = lockedGen.Fill(dst)
+	}
+}
+
+func BenchmarkCcIdGenLocked_NextNonMonotonic(b *testing.B) {
+	r, _ := e.NewHybridRandReaderWithSize(e.SIZE_32k)
+	gen, _ := NewCcIdGen(p.ByteSliceSize128, r)
+	lockedGen := NewCcIdGenLocked(gen)
+	dst := make([]p.CcId, benchmarkBatchSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := range dst {
+			dst[j], _ = lockedGen.Next()
+		}
+	}
+}
+
+func BenchmarkCcIdGenLocked_FillNonMonotonic(b *testing.B) {
+	r, _ := e.NewHybridRandReaderWithSize(e.SIZE_32k)
+	gen, _ := NewCcIdGen(p.ByteSliceSize128, r)
+	lockedGen := NewCcIdGenLocked(gen)
+	dst := make([]p.CcId, benchmarkBatchSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = lockedGen.Fill(dst)
+	}
+}
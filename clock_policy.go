@@ -0,0 +1,191 @@
+package ccid_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	p "github.com/Pencroff/ccid_go/pkg"
+	"time"
+)
+
+// ClockPolicy defines behavior of a monotonic generator when the clock moves backwards.
+// Non-monotonic generators always use the clock as is.
+type ClockPolicy byte
+
+const (
+	// ClockTolerate keeps the last timestamp and mutates payload until the clock catches up, it's the default.
+	ClockTolerate ClockPolicy = iota
+	// ClockBlock sleeps until the clock catches up with the last timestamp.
+	// Payload overflow also waits for the next timestamp instead of moving timestamp into the future.
+	// NextWithTime can't wait for the provided time, it returns ClockMovedBackwardsError instead.
+	// Use NextContext to limit the wait.
+	ClockBlock
+	// ClockError returns ClockMovedBackwardsError until the clock catches up with the last observed time.
+	ClockError
+)
+
+// ErrClockMovedBackwards matches ClockMovedBackwardsError with errors.Is.
+var ErrClockMovedBackwards = errors.New("CCID: clock moved backwards")
+
+// ErrWaitExceedsDeadline is returned by NextContext when waiting for the clock would exceed the context deadline.
+var ErrWaitExceedsDeadline = errors.New("CCID: wait for the next timestamp exceeds context deadline")
+
+// ClockMovedBackwardsError is returned by ClockError and ClockBlock policies when the clock moved backwards.
+type ClockMovedBackwardsError struct {
+	Time time.Time // Time of the clock
+	Last time.Time // Last observed time, truncated to timestamp precision
+}
+
+func (e ClockMovedBackwardsError) Error() string {
+	return fmt.Sprintf("CCID: clock moved backwards %s, last time %s",
+		e.Time.Format(p.RFC3339Milli), e.Last.Format(p.RFC3339Milli))
+}
+
+func (e ClockMovedBackwardsError) Is(target error) bool {
+	return target == ErrClockMovedBackwards
+}
+
+// ClockDriftExceededError is returned when timestamp of the next CcId is ahead of the clock more than allowed by WithMaxDrift.
+type ClockDriftExceededError struct {
+	Drift    time.Duration
+	MaxDrift time.Duration
+}
+
+func (e ClockDriftExceededError) Error() string {
+	return fmt.Sprintf("CCID: clock drift %s exceeds %s", e.Drift, e.MaxDrift)
+}
+
+// Drift returns how far the last generated timestamp is ahead of the clock, in whole timestamp units.
+// It's zero if the clock is at or after the last timestamp.
+func (g *CcIdGenImplementation) Drift() time.Duration {
+	if !g.hasLast {
+		return 0
+	}
+	timestamp, err := g.adjustTimestamp(g.clock.Now())
+	if err != nil || !g.isAfter(g.lastTimestamp, timestamp) {
+		return 0
+	}
+	return g.duration(g.lastTimestamp, timestamp)
+}
+
+// clockReading is the clock reading of a single generator call.
+// 'ctx' is nil when the time is provided by the caller, so the call can't wait for the clock.
+// 'waitCarry' makes monotonic payload overflow wait for the next timestamp, see nextTimestamp.
+type clockReading struct {
+	ctx       context.Context
+	waitCarry bool
+	now       time.Time
+	timestamp uint64
+}
+
+// observe returns 't' as the clock reading of the call and applies the clock policy if the clock moved backwards.
+// 'ctx' is nil when 't' is provided by the caller, so the clock can't catch up.
+func (g *CcIdGenImplementation) observe(ctx context.Context, t time.Time, waitCarry bool) (clockReading, error) {
+	timestamp, err := g.adjustTimestamp(t)
+	if err != nil {
+		return clockReading{}, err
+	}
+	r := clockReading{ctx: ctx, waitCarry: waitCarry, now: t, timestamp: timestamp}
+	if g.strategy != nil && g.hasLast && g.isAfter(g.clockTimestamp, timestamp) {
+		g.metrics.ClockRegressed()
+		switch {
+		case g.clockPolicy == ClockError, g.clockPolicy == ClockBlock && ctx == nil:
+			return r, ClockMovedBackwardsError{Time: t, Last: g.timeOf(g.clockTimestamp)}
+		case g.clockPolicy == ClockBlock:
+			err = g.waitFor(&r, g.lastTimestamp)
+			if err != nil {
+				return r, err
+			}
+		}
+	}
+	g.clockTimestamp = r.timestamp
+	return r, nil
+}
+
+// waitFor sleeps until the clock reaches 'timestamp' and updates the clock reading 'r'.
+// It fails without sleeping if the wait exceeds deadline of the context of 'r'.
+func (g *CcIdGenImplementation) waitFor(r *clockReading, timestamp uint64) error {
+	unit := time.Second
+	if g.millisecond {
+		unit = time.Millisecond
+	}
+	for g.isAfter(timestamp, r.timestamp) {
+		d := g.duration(timestamp, r.timestamp) - r.now.Sub(r.now.Truncate(unit))
+		if deadline, ok := r.ctx.Deadline(); ok && time.Until(deadline) < d {
+			return ErrWaitExceedsDeadline
+		}
+		err := g.sleep(r.ctx, d)
+		if err != nil {
+			return err
+		}
+		t := g.clock.Now()
+		ts, err := g.adjustTimestamp(t)
+		if err != nil {
+			return err
+		}
+		r.now, r.timestamp, g.clockTimestamp = t, ts, ts
+	}
+	return nil
+}
+
+// sleepContext makes 'sleep' interruptible by context, a timer is used if 'sleep' is nil.
+// A custom sleep isn't interrupted, the context is checked before and after it.
+func sleepContext(sleep func(d time.Duration)) func(ctx context.Context, d time.Duration) error {
+	if sleep == nil {
+		return func(ctx context.Context, d time.Duration) error {
+			timer := time.NewTimer(d)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+	return func(ctx context.Context, d time.Duration) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		sleep(d)
+		return ctx.Err()
+	}
+}
+
+// checkDrift returns ClockDriftExceededError if 'timestamp' is ahead of the clock reading 'r' more than allowed.
+func (g *CcIdGenImplementation) checkDrift(r *clockReading, timestamp uint64) error {
+	if g.maxDrift == 0 || !g.isAfter(timestamp, r.timestamp) {
+		return nil
+	}
+	drift := g.duration(timestamp, r.timestamp)
+	if drift > g.maxDrift {
+		return ClockDriftExceededError{Drift: drift, MaxDrift: g.maxDrift}
+	}
+	return nil
+}
+
+// isAfter reports whether timestamp 'a' is after 'b'.
+// Timestamps are compared as serial numbers for p.TimestampRangeWrap, so the next era follows the previous one.
+func (g *CcIdGenImplementation) isAfter(a, b uint64) bool {
+	if g.rangePolicy == p.TimestampRangeWrap {
+		diff := (a - b) & g.maxTimestamp
+		return diff != 0 && diff <= g.maxTimestamp>>1
+	}
+	return a > b
+}
+
+// duration returns time between timestamps 'a' and 'b', 'a' must be after 'b'.
+func (g *CcIdGenImplementation) duration(a, b uint64) time.Duration {
+	diff := (a - b) & g.maxTimestamp
+	if g.millisecond {
+		return time.Duration(diff) * time.Millisecond
+	}
+	return time.Duration(diff) * time.Second
+}
+
+func (g *CcIdGenImplementation) timeOf(timestamp uint64) time.Time {
+	if g.millisecond {
+		return p.ToStandardizedTimeMsWithEpoch(timestamp, g.epoch)
+	}
+	return p.ToStandardizedTimeWithEpoch(uint32(timestamp), g.epoch)
+}
@@ -0,0 +1,245 @@
+package ccid_go
+
+import (
+	"context"
+	"errors"
+	p "github.com/Pencroff/ccid_go/pkg"
+	"testing"
+	"time"
+)
+
+func newClockPolicyGen(t *testing.T, r *mockStaticReader, c *mockStaticClock, sleeps *[]time.Duration, opts ...Option) CcIdGen {
+	opts = append([]Option{WithSize(p.ByteSliceSize96), WithReader(r), WithStrategy(p.NewFiftyPercentMonotonicStrategy(r)),
+		WithClock(c), WithSleep(func(d time.Duration) {
+			*sleeps = append(*sleeps, d)
+			c.Val = c.Val.Add(d)
+		})}, opts...)
+	gen, err := NewGenerator(opts...)
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	return gen
+}
+
+func TestClockPolicy_Backwards(t *testing.T) {
+	mockTime := time.Date(2024, 1, 16, 15, 44, 56, 0, time.UTC)
+	timestamp := p.ToAdjustedTimestamp(mockTime)
+	cases := map[string]struct {
+		opts      []Option
+		timestamp uint32
+		sleeps    []time.Duration
+		target    error
+		drift     time.Duration
+	}{
+		"tolerate":           {nil, timestamp, nil, nil, 3 * time.Second},
+		"tolerate max drift": {[]Option{WithMaxDrift(3 * time.Second)}, timestamp, nil, nil, 3 * time.Second},
+		"drift exceeded":     {[]Option{WithMaxDrift(2 * time.Second)}, 0, nil, ClockDriftExceededError{}, 3 * time.Second},
+		"block":              {[]Option{WithClockPolicy(ClockBlock)}, timestamp, []time.Duration{3 * time.Second}, nil, 0},
+		"error":              {[]Option{WithClockPolicy(ClockError)}, 0, nil, ErrClockMovedBackwards, 3 * time.Second},
+	}
+	for _, key := range p.SortKeys(cases) {
+		tc := cases[key]
+		t.Run(key, func(t *testing.T) {
+			r := &mockStaticReader{Val: 0x10}
+			c := &mockStaticClock{Val: mockTime}
+			var sleeps []time.Duration
+			gen := newClockPolicyGen(t, r, c, &sleeps, tc.opts...)
+			_, _ = gen.Next()
+			c.Val = mockTime.Add(-3 * time.Second)
+			id, err := gen.Next()
+			switch target := tc.target.(type) {
+			case nil:
+				if err != nil || id.Timestamp() != tc.timestamp {
+					t.Errorf("Next() = %d, %v, want %d", id.Timestamp(), err, tc.timestamp)
+				}
+			case ClockDriftExceededError:
+				if !errors.As(err, &target) || target.Drift != 3*time.Second {
+					t.Errorf("Next() error = %v, want ClockDriftExceededError", err)
+				}
+			default:
+				if !errors.Is(err, target) {
+					t.Errorf("Next() error = %v, want %v", err, target)
+				}
+			}
+			if len(sleeps) != len(tc.sleeps) || (len(sleeps) > 0 && sleeps[0] != tc.sleeps[0]) {
+				t.Errorf("sleeps = %v, want %v", sleeps, tc.sleeps)
+			}
+			if gen.Drift() != tc.drift {
+				t.Errorf("Drift() = %v, want %v", gen.Drift(), tc.drift)
+			}
+		})
+	}
+}
+
+func TestClockPolicy_Error(t *testing.T) {
+	mockTime := time.Date(2024, 1, 16, 15, 44, 56, 0, time.UTC)
+	r := &mockStaticReader{Val: 0x10}
+	c := &mockStaticClock{Val: mockTime}
+	var sleeps []time.Duration
+	gen := newClockPolicyGen(t, r, c, &sleeps, WithClockPolicy(ClockError))
+	_, _ = gen.Next()
+	c.Val = mockTime.Add(-time.Second)
+	_, err := gen.Next()
+	var backwardsErr ClockMovedBackwardsError
+	if !errors.As(err, &backwardsErr) || !backwardsErr.Last.Equal(mockTime) || !backwardsErr.Time.Equal(c.Val) {
+		t.Errorf("Next() error = %v, want ClockMovedBackwardsError", err)
+	}
+	c.Val = mockTime
+	id, err := gen.Next()
+	if err != nil || id.Timestamp() != p.ToAdjustedTimestamp(mockTime) {
+		t.Errorf("Next() = %d, %v, want %d", id.Timestamp(), err, p.ToAdjustedTimestamp(mockTime))
+	}
+}
+
+func TestClockPolicy_Block(t *testing.T) {
+	mockTime := time.Date(2024, 1, 16, 15, 44, 56, 0, time.UTC)
+	t.Run("carry", func(t *testing.T) {
+		r := &mockStaticReader{Val: 0xFF}
+		c := &mockStaticClock{Val: mockTime.Add(400 * time.Millisecond)}
+		var sleeps []time.Duration
+		gen := newClockPolicyGen(t, r, c, &sleeps, WithClockPolicy(ClockBlock))
+		idA, _ := gen.Next()
+		idB, err := gen.Next()
+		if err != nil || idB.Timestamp() != idA.Timestamp()+1 {
+			t.Errorf("Next() = %d, %v, want %d", idB.Timestamp(), err, idA.Timestamp()+1)
+		}
+		if len(sleeps) != 1 || sleeps[0] != 600*time.Millisecond {
+			t.Errorf("sleeps = %v, want [600ms]", sleeps)
+		}
+		if gen.Drift() != 0 {
+			t.Errorf("Drift() = %v, want 0", gen.Drift())
+		}
+	})
+	t.Run("carry tolerate", func(t *testing.T) {
+		r := &mockStaticReader{Val: 0xFF}
+		c := &mockStaticClock{Val: mockTime}
+		var sleeps []time.Duration
+		gen := newClockPolicyGen(t, r, c, &sleeps, WithMaxDrift(time.Second))
+		idA, _ := gen.Next()
+		idB, err := gen.Next()
+		if err != nil || idB.Timestamp() != idA.Timestamp()+1 || gen.Drift() != time.Second {
+			t.Errorf("Next() = %d, %v, want %d", idB.Timestamp(), err, idA.Timestamp()+1)
+		}
+		_, err = gen.Next()
+		if !errors.As(err, &ClockDriftExceededError{}) {
+			t.Errorf("Next() error = %v, want ClockDriftExceededError", err)
+		}
+	})
+	t.Run("NextWithTime", func(t *testing.T) {
+		r := &mockStaticReader{Val: 0x10}
+		c := &mockStaticClock{Val: mockTime}
+		var sleeps []time.Duration
+		gen := newClockPolicyGen(t, r, c, &sleeps, WithClockPolicy(ClockBlock))
+		_, _ = gen.NextWithTime(mockTime)
+		_, err := gen.NextWithTime(mockTime.Add(-time.Second))
+		if !errors.Is(err, ErrClockMovedBackwards) || len(sleeps) != 0 {
+			t.Errorf("NextWithTime() error = %v, want ClockMovedBackwardsError without sleep", err)
+		}
+	})
+	t.Run("millisecond", func(t *testing.T) {
+		r := &mockStaticReader{Val: 0x10}
+		c := &mockStaticClock{Val: mockTime}
+		var sleeps []time.Duration
+		gen := newClockPolicyGen(t, r, c, &sleeps, WithClockPolicy(ClockBlock), WithMillisecond())
+		idA, _ := gen.Next()
+		c.Val = mockTime.Add(-1500 * time.Microsecond)
+		idB, err := gen.Next()
+		if err != nil || p.Compare(idA, idB) >= 0 || idB.(p.CcId96Ms).TimestampMs() != idA.(p.CcId96Ms).TimestampMs() {
+			t.Errorf("Next() = %x, %v, want after %x", idB.Bytes(), err, idA.Bytes())
+		}
+		if len(sleeps) != 1 || sleeps[0] != 1500*time.Microsecond {
+			t.Errorf("sleeps = %v, want [1.5ms]", sleeps)
+		}
+	})
+}
+
+func TestClockPolicy_NonMonotonic(t *testing.T) {
+	mockTime := time.Date(2024, 1, 16, 15, 44, 56, 0, time.UTC)
+	c := &mockStaticClock{Val: mockTime}
+	gen, _ := NewGenerator(WithSize(p.ByteSliceSize96), WithReader(&mockReader{}), WithClock(c),
+		WithClockPolicy(ClockError))
+	_, _ = gen.Next()
+	c.Val = mockTime.Add(-time.Second)
+	id, err := gen.Next()
+	if err != nil || !id.Time().Equal(c.Val) {
+		t.Errorf("Next() = %v, %v, want %v", id.Time(), err, c.Val)
+	}
+}
+
+func TestNextContext(t *testing.T) {
+	mockTime := time.Date(2024, 1, 16, 15, 44, 56, 400_000_000, time.UTC)
+	cases := map[string]struct {
+		ctx    func() (context.Context, context.CancelFunc)
+		sleeps []time.Duration
+		err    error
+	}{
+		"background": {func() (context.Context, context.CancelFunc) {
+			return context.WithCancel(context.Background())
+		}, []time.Duration{600 * time.Millisecond}, nil},
+		"deadline": {func() (context.Context, context.CancelFunc) {
+			return context.WithTimeout(context.Background(), time.Minute)
+		}, []time.Duration{600 * time.Millisecond}, nil},
+		"deadline exceeded": {func() (context.Context, context.CancelFunc) {
+			return context.WithTimeout(context.Background(), 100*time.Millisecond)
+		}, nil, ErrWaitExceedsDeadline},
+		"canceled": {func() (context.Context, context.CancelFunc) {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			return ctx, cancel
+		}, nil, context.Canceled},
+	}
+	for _, key := range p.SortKeys(cases) {
+		tc := cases[key]
+		t.Run(key, func(t *testing.T) {
+			r := &mockStaticReader{Val: 0xFF}
+			c := &mockStaticClock{Val: mockTime}
+			var sleeps []time.Duration
+			gen, _ := NewGenerator(WithSize(p.ByteSliceSize64), WithFingerprint([]byte{0x55}), WithReader(r),
+				WithStrategy(p.NewFiftyPercentMonotonicStrategy(r)), WithClock(c), WithSleep(func(d time.Duration) {
+					sleeps = append(sleeps, d)
+					c.Val = c.Val.Add(d)
+				}))
+			ctx, cancel := tc.ctx()
+			defer cancel()
+			idA, _ := gen.Next()
+			idB, err := gen.NextContext(ctx)
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Errorf("NextContext() error = %v, want %v", err, tc.err)
+				}
+			} else if err != nil || idB.Timestamp() != idA.Timestamp()+1 || idB.Timestamp() != p.ToAdjustedTimestamp(c.Val) {
+				t.Errorf("NextContext() = %d, %v, want %d", idB.Timestamp(), err, idA.Timestamp()+1)
+			}
+			if len(sleeps) != len(tc.sleeps) || (len(sleeps) > 0 && sleeps[0] != tc.sleeps[0]) {
+				t.Errorf("sleeps = %v, want %v", sleeps, tc.sleeps)
+			}
+		})
+	}
+}
+
+func TestNextContext_RealClock(t *testing.T) {
+	r := &mockStaticReader{Val: 0xFF}
+	s := p.NewFiftyPercentMonotonicStrategy(r)
+	t.Run("millisecond", func(t *testing.T) {
+		gen, _ := NewGenerator(WithSize(p.ByteSliceSize96), WithReader(r), WithStrategy(s), WithMillisecond())
+		idA, _ := gen.Next()
+		idB, err := gen.NextContext(context.Background())
+		if err != nil || idB.(p.CcId96Ms).TimestampMs() <= idA.(p.CcId96Ms).TimestampMs() || gen.Drift() != 0 {
+			t.Errorf("NextContext() = %x, %v, want after %x without drift", idB.Bytes(), err, idA.Bytes())
+		}
+	})
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		// sleep is blocked until the context is canceled, so the wait never completes before
+		gen, _ := NewGenerator(WithSize(p.ByteSliceSize96), WithReader(r), WithStrategy(s),
+			WithClock(&mockStaticClock{Val: time.Date(2024, 1, 16, 15, 44, 56, 0, time.UTC)}),
+			WithSleep(func(time.Duration) { <-ctx.Done() }))
+		_, _ = gen.Next()
+		time.AfterFunc(10*time.Millisecond, cancel)
+		_, err := gen.NextContext(ctx)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("NextContext() error = %v, want %v", err, context.Canceled)
+		}
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"github.com/Pencroff/fluky/source"
 	"io"
+	"math"
 	r "math/rand"
 )
 
@@ -96,6 +97,14 @@ func NewHybridRandReaderWithSizeAndSource(size uint64, source64 r.Source64) (io.
 	}, nil
 }
 
+// NewSeededRandReader creates a deterministic HybridRandReader
+// It uses the Xoshiro256pp source seeded with 'seed' and never reseeds it, so readers with the same seed
+// produce the same bytes. It's not random enough for production, use it for tests and debugging.
+func NewSeededRandReader(seed int64) io.Reader {
+	r, _ := NewHybridRandReaderWithSizeAndSource(math.MaxUint64, source.NewXoshiro256ppSource(seed))
+	return r
+}
+
 func readSeed() (int64, error) {
 	b := make([]byte, 8)
 	_, err := crand.Read(b)
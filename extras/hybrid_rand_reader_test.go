@@ -137,3 +137,18 @@ func TestNewHybridRandReader_SeedAfterLimit(t *testing.T) {
 	}
 	src.AssertExpectations(t)
 }
+
+func TestNewSeededRandReader(t *testing.T) {
+	a, b := make([]byte, 100), make([]byte, 100)
+	_, _ = NewSeededRandReader(42).Read(a)
+	rd := NewSeededRandReader(42)
+	_, _ = rd.Read(b[:33])
+	_, _ = rd.Read(b[33:])
+	if string(a) != string(b) {
+		t.Errorf("Read() with the same seed = %x, want %x", b, a)
+	}
+	_, _ = NewSeededRandReader(43).Read(b)
+	if string(a) == string(b) {
+		t.Errorf("Read() with another seed = %x, want different", b)
+	}
+}
@@ -0,0 +1,37 @@
+package extras
+
+import (
+	"sync"
+	"time"
+)
+
+// ScriptedClock is a deterministic clock for tests and debugging, it implements p.Clock interface.
+// It returns the scripted times in order, after the script it advances the last time by 'step' on every call.
+type ScriptedClock struct {
+	m     sync.Mutex
+	times []time.Time
+	step  time.Duration
+	last  time.Time
+}
+
+// Now implements p.Clock interface for ScriptedClock
+func (c *ScriptedClock) Now() time.Time {
+	c.m.Lock()
+	defer c.m.Unlock()
+	if len(c.times) > 0 {
+		c.last, c.times = c.times[0], c.times[1:]
+		return c.last
+	}
+	c.last = c.last.Add(c.step)
+	return c.last
+}
+
+// NewScriptedClock creates a new ScriptedClock
+// 'start' is the first time, 'step' is added on every following call, e.g. NewScriptedClock(start, time.Millisecond).
+// 'times' are returned after 'start' before stepping, they can go backwards to reproduce clock regressions.
+func NewScriptedClock(start time.Time, step time.Duration, times ...time.Time) *ScriptedClock {
+	return &ScriptedClock{
+		times: append([]time.Time{start}, times...),
+		step:  step,
+	}
+}
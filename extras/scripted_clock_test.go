@@ -0,0 +1,20 @@
+package extras
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScriptedClock(t *testing.T) {
+	start := time.Date(2024, 1, 16, 15, 44, 56, 0, time.UTC)
+	c := NewScriptedClock(start, time.Second, start.Add(time.Minute), start.Add(-time.Minute))
+	want := []time.Time{
+		start, start.Add(time.Minute), start.Add(-time.Minute),
+		start.Add(-time.Minute + time.Second), start.Add(-time.Minute + 2*time.Second),
+	}
+	for i, w := range want {
+		if got := c.Now(); !got.Equal(w) {
+			t.Errorf("Now() call %d = %v, want %v", i, got, w)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+// Package fingerprint derives CcId fingerprints from host, process and environment.
+//
+// Providers hash their source with SHA-256 and truncate the hash to the requested size,
+// 1 byte for CcId64 and up to p.MaxFingerprintSize bytes for other CcIds:
+//
+//	fp, err := fingerprint.Compose(fingerprint.Hostname(), fingerprint.PID()).Fingerprint(p.MaxFingerprintSize)
+//	gen, err := c.NewCcIdGenWithFingerprint(p.ByteSliceSize128, fp, r)
+package fingerprint
+
+import (
+	"crypto/sha256"
+	"fmt"
+	p "github.com/Pencroff/ccid_go/pkg"
+	"math"
+)
+
+// Provider derives a fingerprint from its source.
+type Provider interface {
+	// Fingerprint returns fingerprint of 'size' bytes.
+	// 'size' must be from 1 to p.MaxFingerprintSize.
+	Fingerprint(size byte) ([]byte, error)
+	// CollisionProbability returns probability that at least two of 'instances' get the same fingerprint
+	// of 'size' bytes, assuming every instance has a distinct source value.
+	CollisionProbability(size byte, instances int) float64
+}
+
+// UnavailableError is returned when the source of a provider is not available, e.g. env variable is not set.
+// The value is the source name.
+type UnavailableError string
+
+func (e UnavailableError) Error() string {
+	return fmt.Sprintf("CCID: fingerprint source %s is not available", string(e))
+}
+
+// hashProvider hashes the source value, 'bits' limits entropy of the source, 0 - unlimited.
+type hashProvider struct {
+	source func() ([]byte, error)
+	bits   int
+}
+
+func (h hashProvider) Fingerprint(size byte) ([]byte, error) {
+	if err := validateSize(size); err != nil {
+		return nil, err
+	}
+	v, err := h.source()
+	if err != nil {
+		return nil, err
+	}
+	return hash(size, v), nil
+}
+
+func (h hashProvider) CollisionProbability(size byte, instances int) float64 {
+	bits := 8 * int(size)
+	if h.bits > 0 && h.bits < bits {
+		bits = h.bits
+	}
+	return birthday(bits, instances)
+}
+
+// String returns a provider of the explicit value 's', e.g. a service instance name.
+func String(s string) Provider {
+	return hashProvider{source: func() ([]byte, error) {
+		if s == "" {
+			return nil, UnavailableError("string")
+		}
+		return []byte(s), nil
+	}}
+}
+
+type composeProvider []Provider
+
+// Compose returns a provider hashing fingerprints of all 'providers' together.
+// It fails if any of the providers fails.
+// Instances collide only if every provider collides or the hash collides,
+// so the collision probability is lower than of each provider.
+func Compose(providers ...Provider) Provider {
+	return composeProvider(providers)
+}
+
+func (c composeProvider) Fingerprint(size byte) ([]byte, error) {
+	if err := validateSize(size); err != nil {
+		return nil, err
+	}
+	if len(c) == 0 {
+		return nil, UnavailableError("compose")
+	}
+	v := make([]byte, 0, len(c)*p.MaxFingerprintSize)
+	for _, provider := range c {
+		fp, err := provider.Fingerprint(p.MaxFingerprintSize)
+		if err != nil {
+			return nil, err
+		}
+		v = append(v, fp...)
+	}
+	return hash(size, v), nil
+}
+
+func (c composeProvider) CollisionProbability(size byte, instances int) float64 {
+	if len(c) == 0 {
+		return 1
+	}
+	res := 1.0
+	for _, provider := range c {
+		res *= provider.CollisionProbability(p.MaxFingerprintSize, instances)
+	}
+	return math.Min(1, res+birthday(8*int(size), instances))
+}
+
+func validateSize(size byte) error {
+	if size == 0 || size > p.MaxFingerprintSize {
+		return p.InvalidFingerprintSizeError{
+			ProvidedSize: size,
+			RequiredSize: p.MaxFingerprintSize,
+		}
+	}
+	return nil
+}
+
+func hash(size byte, v []byte) []byte {
+	sum := sha256.Sum256(v)
+	return sum[:size]
+}
+
+// birthday returns probability of at least one collision among 'n' random values of 'bits' bits.
+func birthday(bits int, n int) float64 {
+	if n < 2 {
+		return 0
+	}
+	space := math.Exp2(float64(bits))
+	pairs := float64(n) * float64(n-1) / 2
+	return -math.Expm1(-pairs / space)
+}
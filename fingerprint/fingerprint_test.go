@@ -0,0 +1,91 @@
+package fingerprint
+
+import (
+	"crypto/sha256"
+	"errors"
+	p "github.com/Pencroff/ccid_go/pkg"
+	"math"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	sum := sha256.Sum256([]byte("instance-1"))
+	for size := byte(1); size <= p.MaxFingerprintSize; size++ {
+		fp, err := String("instance-1").Fingerprint(size)
+		if err != nil || !p.SliceEqual(fp, sum[:size]) {
+			t.Errorf("Fingerprint(%d) = %x, %v, want %x", size, fp, err, sum[:size])
+		}
+	}
+	other, _ := String("instance-2").Fingerprint(p.MaxFingerprintSize)
+	if p.SliceEqual(other, sum[:p.MaxFingerprintSize]) {
+		t.Errorf("Fingerprint() of distinct strings = %x, want different", other)
+	}
+}
+
+func TestFingerprint_Error(t *testing.T) {
+	var fpErr p.InvalidFingerprintSizeError
+	var unavailableErr UnavailableError
+	cases := map[string]struct {
+		provider Provider
+		size     byte
+		target   any
+	}{
+		"zero size":       {String("a"), 0, &fpErr},
+		"large size":      {String("a"), p.MaxFingerprintSize + 1, &fpErr},
+		"compose size":    {Compose(String("a")), p.MaxFingerprintSize + 1, &fpErr},
+		"empty string":    {String(""), 1, &unavailableErr},
+		"empty compose":   {Compose(), 1, &unavailableErr},
+		"compose failing": {Compose(String("a"), String("")), 1, &unavailableErr},
+	}
+	for _, key := range p.SortKeys(cases) {
+		c := cases[key]
+		t.Run(key, func(t *testing.T) {
+			fp, err := c.provider.Fingerprint(c.size)
+			if !errors.As(err, c.target) || fp != nil {
+				t.Errorf("Fingerprint() = %x, %v, want %T", fp, err, c.target)
+			}
+		})
+	}
+}
+
+func TestCompose(t *testing.T) {
+	a, b := String("a"), String("b")
+	fpA, _ := a.Fingerprint(p.MaxFingerprintSize)
+	fpB, _ := b.Fingerprint(p.MaxFingerprintSize)
+	sum := sha256.Sum256(append(fpA, fpB...))
+	fp, err := Compose(a, b).Fingerprint(3)
+	if err != nil || !p.SliceEqual(fp, sum[:3]) {
+		t.Errorf("Fingerprint() = %x, %v, want %x", fp, err, sum[:3])
+	}
+	reversed, _ := Compose(b, a).Fingerprint(3)
+	if p.SliceEqual(fp, reversed) {
+		t.Errorf("Fingerprint() of reversed providers = %x, want different", reversed)
+	}
+}
+
+func TestCollisionProbability(t *testing.T) {
+	cases := map[string]struct {
+		provider  Provider
+		size      byte
+		instances int
+		want      float64
+	}{
+		"single instance":  {String("a"), 1, 1, 0},
+		"1 byte":           {String("a"), 1, 20, 1 - math.Exp(-190.0/256)},
+		"5 bytes":          {String("a"), 5, 1000, 1 - math.Exp(-499500.0/(1<<40))},
+		"pid 5 bytes":      {PID(), 5, 1000, 1 - math.Exp(-499500.0/(1<<22))},
+		"pid 1 byte":       {PID(), 1, 20, 1 - math.Exp(-190.0/256)},
+		"compose":          {Compose(PID(), PID()), 2, 100, math.Pow(1-math.Exp(-4950.0/(1<<22)), 2) + 1 - math.Exp(-4950.0/(1<<16))},
+		"empty compose":    {Compose(), 2, 100, 1},
+		"saturated 1 byte": {String("a"), 1, 10000, 1},
+	}
+	for _, key := range p.SortKeys(cases) {
+		c := cases[key]
+		t.Run(key, func(t *testing.T) {
+			got := c.provider.CollisionProbability(c.size, c.instances)
+			if math.Abs(got-c.want) > 1e-12 {
+				t.Errorf("CollisionProbability(%d, %d) = %g, want %g", c.size, c.instances, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package fingerprint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Lease is a fingerprint slot claimed by the process with an advisory lock on a file in a shared directory.
+// Processes on one host acquiring leases in the same directory get distinct slots.
+// The lease is held until Release or process exit, the OS releases the lock of a crashed process,
+// so its slot becomes free. Lock files stay in the directory and are reused.
+//
+// Lease implements Provider, the slot is used as fingerprint as is, so it isn't unique across hosts.
+type Lease struct {
+	m    sync.Mutex
+	slot int
+	file *os.File
+}
+
+// SlotOverflowError is returned when a lease slot doesn't fit the fingerprint size.
+type SlotOverflowError struct {
+	Slot int
+	Size byte
+}
+
+func (e SlotOverflowError) Error() string {
+	return fmt.Sprintf("CCID: lease slot %d doesn't fit fingerprint of %d bytes", e.Slot, e.Size)
+}
+
+// AcquireLease claims the first free slot from 0 to slots-1 in 'dir', the directory must exist.
+// Slots are locked with flock, it's supported on Linux, macOS and BSD.
+// UnavailableError is returned if all slots are taken or file locks are not supported.
+func AcquireLease(dir string, slots int) (*Lease, error) {
+	for slot := 0; slot < slots; slot++ {
+		f, ok, err := lockFile(filepath.Join(dir, fmt.Sprintf("ccid-slot-%03d.lock", slot)))
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			// pid is informational, it helps to find the owner of the slot
+			_ = f.Truncate(0)
+			_, _ = f.WriteAt([]byte(fmt.Sprintf("%d\n", os.Getpid())), 0)
+			return &Lease{slot: slot, file: f}, nil
+		}
+	}
+	return nil, UnavailableError(fmt.Sprintf("lease slot in %s", dir))
+}
+
+// Slot returns the claimed slot.
+func (l *Lease) Slot() int {
+	return l.slot
+}
+
+// Release unlocks the slot, so another process can claim it. It's safe to call it more than once.
+func (l *Lease) Release() error {
+	l.m.Lock()
+	defer l.m.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	err := unlockFile(l.file)
+	if closeErr := l.file.Close(); err == nil {
+		err = closeErr
+	}
+	l.file = nil
+	return err
+}
+
+// Fingerprint implements Provider interface, it returns the slot as big-endian number of 'size' bytes.
+// SlotOverflowError is returned if the slot doesn't fit 'size'.
+func (l *Lease) Fingerprint(size byte) ([]byte, error) {
+	if err := validateSize(size); err != nil {
+		return nil, err
+	}
+	if uint64(l.slot) >= 1<<(8*uint64(size)) {
+		return nil, SlotOverflowError{Slot: l.slot, Size: size}
+	}
+	fp := make([]byte, size)
+	for i, v := size-1, l.slot; v > 0; i, v = i-1, v>>8 {
+		fp[i] = byte(v)
+	}
+	return fp, nil
+}
+
+// CollisionProbability implements Provider interface.
+// Slots are distinct within a host, so it's 0 unless 'instances' exceed slots available for 'size'.
+func (l *Lease) CollisionProbability(size byte, instances int) float64 {
+	if uint64(instances) > 1<<(8*uint64(size)) {
+		return 1
+	}
+	return 0
+}
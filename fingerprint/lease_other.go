@@ -0,0 +1,14 @@
+//go:build !(linux || darwin || dragonfly || freebsd || netbsd || openbsd)
+
+package fingerprint
+
+import "os"
+
+// lockFile reports file locks as unavailable, flock is not supported on this platform.
+func lockFile(string) (*os.File, bool, error) {
+	return nil, false, UnavailableError("file lock")
+}
+
+func unlockFile(*os.File) error {
+	return nil
+}
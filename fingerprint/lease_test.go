@@ -0,0 +1,156 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+package fingerprint
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	p "github.com/Pencroff/ccid_go/pkg"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const leaseHelperEnv = "CCID_LEASE_HELPER_DIR"
+
+// TestLeaseHelperProcess is not a real test, it's a worker process started by TestLease_Processes.
+// It acquires a lease, prints the slot and holds the lease until stdin is closed.
+func TestLeaseHelperProcess(t *testing.T) {
+	dir := os.Getenv(leaseHelperEnv)
+	if dir == "" {
+		return
+	}
+	lease, err := AcquireLease(dir, 3)
+	if err != nil {
+		fmt.Println("error", err)
+		os.Exit(1)
+	}
+	fmt.Println("slot", lease.Slot())
+	_, _ = io.Copy(io.Discard, os.Stdin)
+	os.Exit(0)
+}
+
+type leaseWorker struct {
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	slot  int
+}
+
+func startLeaseWorker(t *testing.T, dir string) *leaseWorker {
+	cmd := exec.Command(os.Args[0], "-test.run=^TestLeaseHelperProcess$")
+	cmd.Env = append(os.Environ(), leaseHelperEnv+"="+dir)
+	stdin, _ := cmd.StdinPipe()
+	stdout, _ := cmd.StdoutPipe()
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("worker start error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	})
+	line, _ := bufio.NewReader(stdout).ReadString('\n')
+	slot, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(line), "slot "))
+	if err != nil {
+		t.Fatalf("worker output = %q, want slot", line)
+	}
+	return &leaseWorker{cmd: cmd, stdin: stdin, slot: slot}
+}
+
+func TestLease_Processes(t *testing.T) {
+	dir := t.TempDir()
+	workers := make([]*leaseWorker, 3)
+	for i := range workers {
+		workers[i] = startLeaseWorker(t, dir)
+		if workers[i].slot != i {
+			t.Errorf("worker %d slot = %d, want %d", i, workers[i].slot, i)
+		}
+	}
+	var unavailableErr UnavailableError
+	if _, err := AcquireLease(dir, 3); !errors.As(err, &unavailableErr) {
+		t.Fatalf("AcquireLease() error = %v, want UnavailableError", err)
+	}
+	// crashed worker releases its slot
+	_ = workers[1].cmd.Process.Kill()
+	_ = workers[1].cmd.Wait()
+	lease, err := AcquireLease(dir, 3)
+	if err != nil || lease.Slot() != 1 {
+		t.Fatalf("AcquireLease() = %v, %v, want slot 1", lease, err)
+	}
+	// worker exiting normally releases its slot
+	_ = workers[2].stdin.Close()
+	_ = workers[2].cmd.Wait()
+	w := startLeaseWorker(t, dir)
+	if w.slot != 2 {
+		t.Errorf("worker slot = %d, want 2", w.slot)
+	}
+	_ = lease.Release()
+}
+
+func TestLease(t *testing.T) {
+	dir := t.TempDir()
+	leases := make([]*Lease, 3)
+	for i := range leases {
+		lease, err := AcquireLease(dir, 300)
+		if err != nil || lease.Slot() != i {
+			t.Fatalf("AcquireLease() = %v, %v, want slot %d", lease, err, i)
+		}
+		leases[i] = lease
+	}
+	if err := leases[1].Release(); err != nil {
+		t.Errorf("Release() error = %v", err)
+	}
+	if err := leases[1].Release(); err != nil {
+		t.Errorf("second Release() error = %v", err)
+	}
+	lease, err := AcquireLease(dir, 300)
+	if err != nil || lease.Slot() != 1 {
+		t.Errorf("AcquireLease() = %v, %v, want slot 1", lease, err)
+	}
+	if _, err = AcquireLease(dir+"/missing", 1); err == nil {
+		t.Errorf("AcquireLease() error = nil, want error for missing directory")
+	}
+}
+
+func TestLease_Fingerprint(t *testing.T) {
+	var fpErr p.InvalidFingerprintSizeError
+	var overflowErr SlotOverflowError
+	cases := map[string]struct {
+		slot   int
+		size   byte
+		want   []byte
+		target any
+	}{
+		"slot 0":        {0, 1, []byte{0}, nil},
+		"slot 7":        {7, 3, []byte{0, 0, 7}, nil},
+		"slot 300":      {300, 2, []byte{0x01, 0x2c}, nil},
+		"slot too big":  {300, 1, nil, &overflowErr},
+		"zero size":     {1, 0, nil, &fpErr},
+		"max size slot": {0x0102, p.MaxFingerprintSize, []byte{0, 0, 0, 1, 2}, nil},
+	}
+	for _, key := range p.SortKeys(cases) {
+		c := cases[key]
+		t.Run(key, func(t *testing.T) {
+			fp, err := (&Lease{slot: c.slot}).Fingerprint(c.size)
+			if c.target != nil {
+				if !errors.As(err, c.target) {
+					t.Errorf("Fingerprint() error = %v, want %T", err, c.target)
+				}
+				return
+			}
+			if err != nil || !p.SliceEqual(fp, c.want) {
+				t.Errorf("Fingerprint() = %x, %v, want %x", fp, err, c.want)
+			}
+		})
+	}
+	lease := &Lease{slot: 3}
+	if v := lease.CollisionProbability(1, 256); v != 0 {
+		t.Errorf("CollisionProbability(1, 256) = %g, want 0", v)
+	}
+	if v := lease.CollisionProbability(1, 257); v != 1 {
+		t.Errorf("CollisionProbability(1, 257) = %g, want 1", v)
+	}
+}
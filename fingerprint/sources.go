@@ -0,0 +1,72 @@
+package fingerprint
+
+import (
+	"encoding/binary"
+	"os"
+	"strings"
+)
+
+// pidBits is entropy of process id, Linux pid_max is up to 2^22.
+const pidBits = 22
+
+// machineIdPaths are locations of machine id generated by systemd or dbus.
+var machineIdPaths = []string{"/etc/machine-id", "/var/lib/dbus/machine-id"}
+
+// ContainerEnv is a list of env variables identifying a container or Kubernetes pod, see Env.
+var ContainerEnv = []string{"POD_NAME", "POD_NAMESPACE", "POD_UID", "CONTAINER_ID", "HOSTNAME"}
+
+// Hostname returns a provider of the host name.
+func Hostname() Provider {
+	return hashProvider{source: func() ([]byte, error) {
+		name, err := os.Hostname()
+		if err != nil {
+			return nil, err
+		}
+		if name == "" {
+			return nil, UnavailableError("hostname")
+		}
+		return []byte(name), nil
+	}}
+}
+
+// MachineId returns a provider of the machine id from /etc/machine-id or /var/lib/dbus/machine-id.
+// It's available on Linux with systemd or dbus.
+func MachineId() Provider {
+	return hashProvider{source: func() ([]byte, error) {
+		for _, path := range machineIdPaths {
+			b, err := os.ReadFile(path)
+			if id := strings.TrimSpace(string(b)); err == nil && id != "" {
+				return []byte(id), nil
+			}
+		}
+		return nil, UnavailableError("machine-id")
+	}}
+}
+
+// PID returns a provider of the current process id.
+// Process ids are unique only within a host, compose it with Hostname or MachineId.
+func PID() Provider {
+	return hashProvider{source: func() ([]byte, error) {
+		return binary.BigEndian.AppendUint32(nil, uint32(os.Getpid())), nil
+	}, bits: pidBits}
+}
+
+// Env returns a provider of the given env variables, e.g. Env(ContainerEnv...).
+// Unset variables are skipped, it fails if none of them is set.
+func Env(names ...string) Provider {
+	return hashProvider{source: func() ([]byte, error) {
+		var b []byte
+		for _, name := range names {
+			if v, ok := os.LookupEnv(name); ok {
+				b = append(b, name...)
+				b = append(b, '=')
+				b = append(b, v...)
+				b = append(b, 0)
+			}
+		}
+		if b == nil {
+			return nil, UnavailableError("env " + strings.Join(names, ","))
+		}
+		return b, nil
+	}}
+}
@@ -0,0 +1,60 @@
+package fingerprint
+
+import (
+	"errors"
+	p "github.com/Pencroff/ccid_go/pkg"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHostname(t *testing.T) {
+	name, err := os.Hostname()
+	if err != nil || name == "" {
+		t.Skip("hostname is not available")
+	}
+	want, _ := String(name).Fingerprint(p.MaxFingerprintSize)
+	fp, err := Hostname().Fingerprint(p.MaxFingerprintSize)
+	if err != nil || !p.SliceEqual(fp, want) {
+		t.Errorf("Fingerprint() = %x, %v, want %x", fp, err, want)
+	}
+}
+
+func TestMachineId(t *testing.T) {
+	dir := t.TempDir()
+	paths := machineIdPaths
+	defer func() { machineIdPaths = paths }()
+	machineIdPaths = []string{filepath.Join(dir, "missing"), filepath.Join(dir, "machine-id")}
+	var unavailableErr UnavailableError
+	if _, err := MachineId().Fingerprint(1); !errors.As(err, &unavailableErr) {
+		t.Errorf("Fingerprint() error = %v, want UnavailableError", err)
+	}
+	_ = os.WriteFile(machineIdPaths[1], []byte("0123456789abcdef\n"), 0o600)
+	want, _ := String("0123456789abcdef").Fingerprint(4)
+	fp, err := MachineId().Fingerprint(4)
+	if err != nil || !p.SliceEqual(fp, want) {
+		t.Errorf("Fingerprint() = %x, %v, want %x", fp, err, want)
+	}
+}
+
+func TestPID(t *testing.T) {
+	fpA, errA := PID().Fingerprint(2)
+	fpB, errB := PID().Fingerprint(2)
+	if errA != nil || errB != nil || len(fpA) != 2 || !p.SliceEqual(fpA, fpB) {
+		t.Errorf("Fingerprint() = %x, %v and %x, %v, want equal 2 bytes", fpA, errA, fpB, errB)
+	}
+}
+
+func TestEnv(t *testing.T) {
+	t.Setenv("CCID_TEST_POD", "pod-1")
+	t.Setenv("CCID_TEST_NAMESPACE", "default")
+	fp, err := Env("CCID_TEST_POD", "CCID_TEST_UNSET", "CCID_TEST_NAMESPACE").Fingerprint(3)
+	want, _ := String("CCID_TEST_POD=pod-1\x00CCID_TEST_NAMESPACE=default\x00").Fingerprint(3)
+	if err != nil || !p.SliceEqual(fp, want) {
+		t.Errorf("Fingerprint() = %x, %v, want %x", fp, err, want)
+	}
+	var unavailableErr UnavailableError
+	if _, err = Env("CCID_TEST_UNSET").Fingerprint(1); !errors.As(err, &unavailableErr) {
+		t.Errorf("Fingerprint() error = %v, want UnavailableError", err)
+	}
+}
@@ -0,0 +1,128 @@
+package ccid_go
+
+import (
+	"context"
+	p "github.com/Pencroff/ccid_go/pkg"
+	"time"
+)
+
+// CcIdValue is a constraint of concrete CcId types generated by Gen.
+type CcIdValue interface {
+	p.CcId64 | p.CcId96 | p.CcId128 | p.CcId160 | p.CcId96Ms | p.CcId128Ms | p.CcId160Ms
+}
+
+// Gen is a typed CcId generator returning the concrete CcId type 'T' by value.
+// Unlike CcIdGen it doesn't box CcIds into p.CcId interface, so Gen itself doesn't allocate per CcId:
+// Next is allocation free with an allocation free reader, monotonic strategies allocate in Mutate.
+// It has the same behavior as CcIdGenImplementation with the same options and it's not thread-safe either.
+type Gen[T CcIdValue] struct {
+	gen  *CcIdGenImplementation
+	ctor func(timestamp uint64, fingerprint []byte, payload []byte) (T, error)
+}
+
+// NewGen creates a new Gen of 'T' configured by 'opts', see NewGenerator.
+// Size and precision are defined by 'T', so WithSize and WithMillisecond are not required.
+func NewGen[T CcIdValue](opts ...Option) (*Gen[T], error) {
+	var zero T
+	var size byte
+	millisecond := false
+	switch any(zero).(type) {
+	case p.CcId64:
+		size = p.ByteSliceSize64
+	case p.CcId96:
+		size = p.ByteSliceSize96
+	case p.CcId128:
+		size = p.ByteSliceSize128
+	case p.CcId160:
+		size = p.ByteSliceSize160
+	case p.CcId96Ms:
+		size, millisecond = p.ByteSliceSize96, true
+	case p.CcId128Ms:
+		size, millisecond = p.ByteSliceSize128, true
+	case p.CcId160Ms:
+		size, millisecond = p.ByteSliceSize160, true
+	}
+	opts = append(opts, WithSize(size), func(cfg *generatorConfig) {
+		cfg.millisecond = millisecond
+	})
+	cfg, err := newGeneratorConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	gen := newGenerator(cfg)
+	err = gen.restore()
+	if err != nil {
+		return nil, err
+	}
+	return &Gen[T]{gen: gen, ctor: valueCtor[T]()}, nil
+}
+
+// valueCtor returns the value constructor of 'T'.
+func valueCtor[T CcIdValue]() func(uint64, []byte, []byte) (T, error) {
+	var ctor any
+	switch any(*new(T)).(type) {
+	case p.CcId64:
+		ctor = func(timestamp uint64, fingerprint []byte, payload []byte) (p.CcId64, error) {
+			return p.NewCcId64Value(uint32(timestamp), fingerprint, payload)
+		}
+	case p.CcId96:
+		ctor = func(timestamp uint64, fingerprint []byte, payload []byte) (p.CcId96, error) {
+			return p.NewCcId96Value(uint32(timestamp), fingerprint, payload)
+		}
+	case p.CcId128:
+		ctor = func(timestamp uint64, fingerprint []byte, payload []byte) (p.CcId128, error) {
+			return p.NewCcId128Value(uint32(timestamp), fingerprint, payload)
+		}
+	case p.CcId160:
+		ctor = func(timestamp uint64, fingerprint []byte, payload []byte) (p.CcId160, error) {
+			return p.NewCcId160Value(uint32(timestamp), fingerprint, payload)
+		}
+	case p.CcId96Ms:
+		ctor = p.NewCcId96MsValue
+	case p.CcId128Ms:
+		ctor = p.NewCcId128MsValue
+	case p.CcId160Ms:
+		ctor = p.NewCcId160MsValue
+	}
+	return ctor.(func(uint64, []byte, []byte) (T, error))
+}
+
+// Next generates the next CcId using the current time, see CcIdGen.Next.
+// It returns zero 'T' on error.
+func (g *Gen[T]) Next() (T, error) {
+	return g.nextOne(context.Background(), g.gen.clock.Now(), g.gen.clockPolicy == ClockBlock)
+}
+
+// NextContext generates the next CcId using the current time, see CcIdGen.NextContext.
+func (g *Gen[T]) NextContext(ctx context.Context) (T, error) {
+	return g.nextOne(ctx, g.gen.clock.Now(), true)
+}
+
+// NextWithTime generates the next CcId using the provided time, see CcIdGen.NextWithTime.
+func (g *Gen[T]) NextWithTime(t time.Time) (T, error) {
+	return g.nextOne(nil, t, false)
+}
+
+// Fill generates CcIds into every element of 'dst' using the current time, see CcIdGen.Fill.
+func (g *Gen[T]) Fill(dst []T) error {
+	return generate(g.gen, context.Background(), g.gen.clock.Now(), g.gen.clockPolicy == ClockBlock, dst, g.ctor)
+}
+
+// Drift returns how far the last generated timestamp is ahead of the clock, see ClockPolicy.
+func (g *Gen[T]) Drift() time.Duration {
+	return g.gen.Drift()
+}
+
+// Close saves the exact state of the generator to the store, see CcIdGenImplementation.Close.
+func (g *Gen[T]) Close() error {
+	return g.gen.Close()
+}
+
+func (g *Gen[T]) nextOne(ctx context.Context, t time.Time, waitCarry bool) (T, error) {
+	var res [1]T
+	err := generate(g.gen, ctx, t, waitCarry, res[:], g.ctor)
+	if err != nil {
+		return *new(T), err
+	}
+	return res[0], nil
+}
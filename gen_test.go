@@ -0,0 +1,152 @@
+package ccid_go
+
+import (
+	"errors"
+	e "github.com/Pencroff/ccid_go/extras"
+	p "github.com/Pencroff/ccid_go/pkg"
+	"testing"
+	"time"
+)
+
+func testGen[T interface {
+	CcIdValue
+	p.CcId
+}](t *testing.T, opts ...Option) {
+	mockTime := time.Date(2024, 1, 16, 15, 44, 56, 0, time.UTC)
+	r := &mockReader{}
+	gen, err := NewGen[T](append([]Option{WithReader(r), WithStrategy(p.NewIncreaseMonotonicStrategy()),
+		WithClock(&mockStaticClock{Val: mockTime})}, opts...)...)
+	if err != nil {
+		t.Fatalf("NewGen() error = %v", err)
+	}
+	rd := &mockReader{}
+	var zero T
+	opts = append([]Option{WithSize(byte(len(zero.Bytes()))), WithReader(rd), WithStrategy(p.NewIncreaseMonotonicStrategy()),
+		WithClock(&mockStaticClock{Val: mockTime})}, opts...)
+	if _, ok := any(zero).(interface{ TimestampMs() uint64 }); ok {
+		opts = append(opts, WithMillisecond())
+	}
+	expected, err := NewGenerator(opts...)
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	dst := make([]T, 3)
+	want := make([]p.CcId, 3)
+	_ = expected.Fill(want)
+	err = gen.Fill(dst)
+	if err != nil {
+		t.Fatalf("Fill() error = %v", err)
+	}
+	next, err := gen.Next()
+	dst = append(dst, next)
+	wantNext, _ := expected.Next()
+	want = append(want, wantNext)
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	for i, id := range dst {
+		if !p.SliceEqual(id.Bytes(), want[i].Bytes()) || !id.Time().Equal(want[i].Time()) {
+			t.Errorf("id[%d] = %x %s, want %x %s", i, id.Bytes(), id.Time(), want[i].Bytes(), want[i].Time())
+		}
+	}
+}
+
+func TestGen(t *testing.T) {
+	epoch := []Option{WithEpoch(p.UnixEpoch)}
+	fingerprint := []Option{WithFingerprint([]byte{0xAA, 0xBB})}
+	t.Run("ccid64", func(t *testing.T) { testGen[p.CcId64](t, WithFingerprint([]byte{0xAA})) })
+	t.Run("ccid96", func(t *testing.T) { testGen[p.CcId96](t, fingerprint...) })
+	t.Run("ccid128", func(t *testing.T) { testGen[p.CcId128](t, fingerprint...) })
+	t.Run("ccid160", func(t *testing.T) { testGen[p.CcId160](t, fingerprint...) })
+	t.Run("ccid96ms", func(t *testing.T) { testGen[p.CcId96Ms](t, fingerprint...) })
+	t.Run("ccid128ms", func(t *testing.T) { testGen[p.CcId128Ms](t, fingerprint...) })
+	t.Run("ccid160ms", func(t *testing.T) { testGen[p.CcId160Ms](t, fingerprint...) })
+	t.Run("ccid96 epoch", func(t *testing.T) { testGen[p.CcId96](t, epoch...) })
+	t.Run("ccid128ms epoch", func(t *testing.T) { testGen[p.CcId128Ms](t, epoch...) })
+}
+
+func TestGen_Error(t *testing.T) {
+	t.Run("options", func(t *testing.T) {
+		_, err := NewGen[p.CcId64](WithReader(&mockReader{}), WithFingerprint([]byte{1, 2, 3, 4}))
+		if !errors.As(err, &p.InvalidFingerprintSizeError{}) {
+			t.Errorf("NewGen() error = %v, want InvalidFingerprintSizeError", err)
+		}
+	})
+	t.Run("time out of range", func(t *testing.T) {
+		gen, _ := NewGen[p.CcId96](WithReader(&mockReader{}))
+		id, err := gen.NextWithTime(p.DefaultEpoch.Add(-time.Second))
+		if !errors.As(err, &p.TimestampOutOfRangeError{}) || id != p.NilCcId96 {
+			t.Errorf("NextWithTime() = %x, %v, want TimestampOutOfRangeError", id.Bytes(), err)
+		}
+	})
+	t.Run("clock moved backwards", func(t *testing.T) {
+		c := &mockStaticClock{Val: time.Date(2024, 1, 16, 15, 44, 56, 0, time.UTC)}
+		gen, _ := NewGen[p.CcId128Ms](WithReader(&mockReader{}), WithStrategy(p.NewIncreaseMonotonicStrategy()),
+			WithClock(c), WithClockPolicy(ClockError))
+		_, _ = gen.Next()
+		c.Val = c.Val.Add(-time.Second)
+		id, err := gen.Next()
+		if !errors.Is(err, ErrClockMovedBackwards) || id != p.NilCcId128Ms {
+			t.Errorf("Next() = %x, %v, want ClockMovedBackwardsError", id.Bytes(), err)
+		}
+	})
+}
+
+func TestGen_Allocs(t *testing.T) {
+	gen, _ := NewGen[p.CcId128](WithReader(&mockStaticReader{Val: 0x10}))
+	dst := make([]p.CcId128, 16)
+	if n := testing.AllocsPerRun(100, func() { _, _ = gen.Next() }); n != 0 {
+		t.Errorf("Next() allocs = %v, want 0", n)
+	}
+	if n := testing.AllocsPerRun(100, func() { _ = gen.Fill(dst) }); n != 0 {
+		t.Errorf("Fill() allocs = %v, want 0", n)
+	}
+}
+
+func BenchmarkGen_Next(b *testing.B) {
+	r, _ := e.NewHybridRandReaderWithSize(e.SIZE_32k)
+	gen, _ := NewGen[p.CcId128](WithReader(r))
+	dst := make([]p.CcId128, benchmarkBatchSize)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := range dst {
+			dst[j], _ = gen.Next()
+		}
+	}
+}
+
+func BenchmarkGen_Fill(b *testing.B) {
+	r, _ := e.NewHybridRandReaderWithSize(e.SIZE_32k)
+	gen, _ := NewGen[p.CcId128](WithReader(r))
+	dst := make([]p.CcId128, benchmarkBatchSize)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = gen.Fill(dst)
+	}
+}
+
+func BenchmarkCcIdGenImplementation_Next(b *testing.B) {
+	r, _ := e.NewHybridRandReaderWithSize(e.SIZE_32k)
+	gen, _ := NewGenerator(WithSize(p.ByteSliceSize128), WithReader(r))
+	dst := make([]p.CcId, benchmarkBatchSize)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := range dst {
+			dst[j], _ = gen.Next()
+		}
+	}
+}
+
+func BenchmarkCcIdGenImplementation_Fill(b *testing.B) {
+	r, _ := e.NewHybridRandReaderWithSize(e.SIZE_32k)
+	gen, _ := NewGenerator(WithSize(p.ByteSliceSize128), WithReader(r))
+	dst := make([]p.CcId, benchmarkBatchSize)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = gen.Fill(dst)
+	}
+}
@@ -0,0 +1,26 @@
+//go:build go1.23
+
+package ccid_go
+
+import (
+	p "github.com/Pencroff/ccid_go/pkg"
+	"iter"
+)
+
+// All returns an endless sequence of CcIds generated by 'g', e.g. `for id, err := range All(gen)`.
+// Generator error is yielded with nil CcId and ends the sequence.
+// 'g' can be any CcIdGen, use NewCcIdGenLocked or CcIdGenPool to share the generator between goroutines.
+func All(g CcIdGen) iter.Seq2[p.CcId, error] {
+	return func(yield func(p.CcId, error) bool) {
+		for {
+			id, err := g.Next()
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(id, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,51 @@
+//go:build go1.23
+
+package ccid_go
+
+import (
+	"errors"
+	p "github.com/Pencroff/ccid_go/pkg"
+	"testing"
+)
+
+func TestAll(t *testing.T) {
+	r := &mockReader{}
+	gen, _ := NewMonotonicCcIdGen(p.ByteSliceSize128, r, p.NewFiftyPercentMonotonicStrategy(r))
+	var prev p.CcId = p.NilCcId128
+	count := 0
+	for id, err := range All(NewCcIdGenLocked(gen)) {
+		if err != nil {
+			t.Fatalf("All() error = %v", err)
+		}
+		if p.Compare(prev, id) >= 0 {
+			t.Errorf("CcId %d is not monotonic: %x >= %x", count, prev.Bytes(), id.Bytes())
+		}
+		prev = id
+		count++
+		if count == 100 {
+			break
+		}
+	}
+	if count != 100 {
+		t.Errorf("All() yielded %d CcIds, want 100", count)
+	}
+}
+
+func TestAll_Error(t *testing.T) {
+	gen, _ := NewCcIdGen(p.ByteSliceSize96, &mockFailingReader{Reads: 3})
+	count := 0
+	var lastErr error
+	for id, err := range All(gen) {
+		if err != nil {
+			if id != nil {
+				t.Errorf("All() = %v, want nil CcId with error", id)
+			}
+			lastErr = err
+			continue
+		}
+		count++
+	}
+	if !errors.Is(lastErr, errMockReader) || count != 3 {
+		t.Errorf("All() = %d CcIds, %v, want 3, %v", count, lastErr, errMockReader)
+	}
+}
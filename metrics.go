@@ -0,0 +1,106 @@
+package ccid_go
+
+import (
+	"expvar"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Metrics receives events of a generator, see WithMetrics.
+// Methods are called while generating CcIds, so they must be cheap.
+// Implementation shared between generators, e.g. shards of CcIdGenPool, must be safe for concurrent use.
+type Metrics interface {
+	// Generated is called for every generated CcId.
+	Generated()
+	// Mutated is called when monotonic strategy mutates the last payload.
+	Mutated()
+	// Carried is called when mutated payload overflows and timestamp moves to the next one.
+	Carried()
+	// ReaderError is called when the random reader fails.
+	ReaderError()
+	// ClockRegressed is called when the clock moves backwards, see ClockPolicy.
+	ClockRegressed()
+	// Drift is called with drift of every generated CcId, how far its timestamp is ahead of the clock.
+	Drift(d time.Duration)
+}
+
+// MetricsSnapshot is a plain copy of Counters for exporting to any metrics collector.
+type MetricsSnapshot struct {
+	Generated        uint64
+	Mutations        uint64
+	Carries          uint64
+	ReaderErrors     uint64
+	ClockRegressions uint64
+	Drift            time.Duration // Drift of the last generated CcId
+}
+
+// Counters is a Metrics implementation with atomic counters, it's safe for concurrent use.
+type Counters struct {
+	generated        atomic.Uint64
+	mutations        atomic.Uint64
+	carries          atomic.Uint64
+	readerErrors     atomic.Uint64
+	clockRegressions atomic.Uint64
+	drift            atomic.Int64
+}
+
+// NewCounters creates Counters with zero values.
+func NewCounters() *Counters {
+	return &Counters{}
+}
+
+func (c *Counters) Generated()            { c.generated.Add(1) }
+func (c *Counters) Mutated()              { c.mutations.Add(1) }
+func (c *Counters) Carried()              { c.carries.Add(1) }
+func (c *Counters) ReaderError()          { c.readerErrors.Add(1) }
+func (c *Counters) ClockRegressed()       { c.clockRegressions.Add(1) }
+func (c *Counters) Drift(d time.Duration) { c.drift.Store(int64(d)) }
+
+// Snapshot returns current values of the counters.
+func (c *Counters) Snapshot() MetricsSnapshot {
+	return MetricsSnapshot{
+		Generated:        c.generated.Load(),
+		Mutations:        c.mutations.Load(),
+		Carries:          c.carries.Load(),
+		ReaderErrors:     c.readerErrors.Load(),
+		ClockRegressions: c.clockRegressions.Load(),
+		Drift:            time.Duration(c.drift.Load()),
+	}
+}
+
+// ExpvarExistsError is returned by NewExpvarCounters when the expvar name is already published.
+type ExpvarExistsError string
+
+func (e ExpvarExistsError) Error() string {
+	return fmt.Sprintf("CCID: expvar %s is already published", string(e))
+}
+
+// expvarMu serializes the check and publishing of NewExpvarCounters, expvar.Publish panics on a duplicate name.
+var expvarMu sync.Mutex
+
+// NewExpvarCounters creates Counters published as expvar 'name', e.g. on /debug/vars.
+// The value is a JSON object with fields of MetricsSnapshot, Drift is in nanoseconds.
+// It's safe for concurrent use, only one of concurrent calls with the same name succeeds.
+func NewExpvarCounters(name string) (*Counters, error) {
+	expvarMu.Lock()
+	defer expvarMu.Unlock()
+	if expvar.Get(name) != nil {
+		return nil, ExpvarExistsError(name)
+	}
+	c := NewCounters()
+	expvar.Publish(name, expvar.Func(func() any {
+		return c.Snapshot()
+	}))
+	return c, nil
+}
+
+type noopMetrics struct{}
+
+func (noopMetrics) Generated()          {}
+func (noopMetrics) Mutated()            {}
+func (noopMetrics) Carried()            {}
+func (noopMetrics) ReaderError()        {}
+func (noopMetrics) ClockRegressed()     {}
+func (noopMetrics) Drift(time.Duration) {}
@@ -0,0 +1,103 @@
+package ccid_go
+
+import (
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	p "github.com/Pencroff/ccid_go/pkg"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCounters(t *testing.T) {
+	mockTime := time.Date(2024, 1, 16, 15, 44, 56, 0, time.UTC)
+	r := &mockStaticReader{Val: 0xFF}
+	c := &mockStaticClock{Val: mockTime}
+	counters := NewCounters()
+	gen, _ := NewGenerator(WithSize(p.ByteSliceSize96), WithReader(r), WithStrategy(p.NewFiftyPercentMonotonicStrategy(r)),
+		WithClock(c), WithMetrics(counters))
+	_, _ = gen.Next()
+	if got, want := counters.Snapshot(), (MetricsSnapshot{Generated: 1}); got != want {
+		t.Errorf("Snapshot() = %+v, want %+v", got, want)
+	}
+	_, _ = gen.Next()
+	c.Val = mockTime.Add(-time.Second)
+	_, _ = gen.Next()
+	want := MetricsSnapshot{Generated: 3, Mutations: 2, Carries: 2, ClockRegressions: 1, Drift: 3 * time.Second}
+	if got := counters.Snapshot(); got != want {
+		t.Errorf("Snapshot() = %+v, want %+v", got, want)
+	}
+	c.Val = mockTime.Add(time.Minute)
+	_, _ = gen.Next()
+	want = MetricsSnapshot{Generated: 4, Mutations: 2, Carries: 2, ClockRegressions: 1}
+	if got := counters.Snapshot(); got != want {
+		t.Errorf("Snapshot() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCounters_ReaderError(t *testing.T) {
+	counters := NewCounters()
+	gen, _ := NewGenerator(WithSize(p.ByteSliceSize96), WithReader(&mockFailingReader{}), WithMetrics(counters))
+	_, _ = gen.Next()
+	_ = gen.Fill(make([]p.CcId, 10))
+	if got, want := counters.Snapshot(), (MetricsSnapshot{ReaderErrors: 2}); got != want {
+		t.Errorf("Snapshot() = %+v, want %+v", got, want)
+	}
+}
+
+var expvarSeq atomic.Uint64
+
+// expvarName returns a name not published yet, expvar names can't be reused, e.g. by go test -count=2.
+func expvarName(t *testing.T) string {
+	return fmt.Sprintf("%s_%d", t.Name(), expvarSeq.Add(1))
+}
+
+func TestNewExpvarCounters(t *testing.T) {
+	name := expvarName(t)
+	counters, err := NewExpvarCounters(name)
+	if err != nil {
+		t.Fatalf("NewExpvarCounters() error = %v", err)
+	}
+	gen, _ := NewGenerator(WithSize(p.ByteSliceSize128), WithReader(&mockReader{}), WithMetrics(counters))
+	_, _ = gen.NextN(5)
+	var got MetricsSnapshot
+	err = json.Unmarshal([]byte(expvar.Get(name).String()), &got)
+	if err != nil || got != counters.Snapshot() || got.Generated != 5 {
+		t.Errorf("expvar %s = %+v, %v, want %+v", name, got, err, counters.Snapshot())
+	}
+	var existsErr ExpvarExistsError
+	if _, err = NewExpvarCounters(name); !errors.As(err, &existsErr) {
+		t.Errorf("NewExpvarCounters() error = %v, want ExpvarExistsError", err)
+	}
+}
+
+func TestNewExpvarCounters_Concurrent(t *testing.T) {
+	name := expvarName(t)
+	const n = 8
+	var wg sync.WaitGroup
+	errs := make([]error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = NewExpvarCounters(name)
+		}(i)
+	}
+	wg.Wait()
+	published := 0
+	for _, err := range errs {
+		var existsErr ExpvarExistsError
+		switch {
+		case err == nil:
+			published++
+		case !errors.As(err, &existsErr):
+			t.Errorf("NewExpvarCounters() error = %v, want ExpvarExistsError", err)
+		}
+	}
+	if published != 1 {
+		t.Errorf("NewExpvarCounters() published %d times, want 1", published)
+	}
+}
@@ -0,0 +1,240 @@
+package ccid_go
+
+import (
+	"fmt"
+	"github.com/Pencroff/ccid_go/fingerprint"
+	p "github.com/Pencroff/ccid_go/pkg"
+	"io"
+	"time"
+)
+
+// Option configures a CcId generator created by NewGenerator.
+type Option func(cfg *generatorConfig)
+
+type generatorConfig struct {
+	size        byte
+	fingerprint []byte
+	provider    fingerprint.Provider
+	fpSize      byte
+	rndRd       io.Reader
+	strategy    p.CcIdMonotonicStrategy
+	clock       p.Clock
+	metrics     Metrics
+	epoch       time.Time
+	millisecond bool
+	rangePolicy p.TimestampRangePolicy
+	clockPolicy ClockPolicy
+	maxDrift    time.Duration
+	sleep       func(d time.Duration)
+	store       StateStore
+	lease       time.Duration
+}
+
+// InvalidOptionError is returned by NewGenerator when a required option is missing or has invalid value.
+// The value is the option name, e.g. "WithReader".
+type InvalidOptionError string
+
+func (e InvalidOptionError) Error() string {
+	return fmt.Sprintf("CCID: invalid or missing generator option %s", string(e))
+}
+
+// WithSize sets the size of generated CcIds in bytes, it's required.
+// 'size' must be p.ByteSliceSize64, p.ByteSliceSize96, p.ByteSliceSize128 or p.ByteSliceSize160.
+func WithSize(size byte) Option {
+	return func(cfg *generatorConfig) {
+		cfg.size = size
+	}
+}
+
+// WithFingerprint sets the fingerprint of generated CcIds, no fingerprint by default.
+// 'fingerprint' must be up to 1 byte for CcId64 and up to 5 bytes for other sizes.
+func WithFingerprint(fingerprint []byte) Option {
+	return func(cfg *generatorConfig) {
+		cfg.fingerprint, cfg.provider = fingerprint, nil
+	}
+}
+
+// WithFingerprintProvider sets fingerprint of 'size' bytes derived by 'provider', e.g. fingerprint.Hostname()
+// or a slot claimed by fingerprint.AcquireLease. It replaces WithFingerprint, provider error is returned by NewGenerator.
+func WithFingerprintProvider(provider fingerprint.Provider, size byte) Option {
+	return func(cfg *generatorConfig) {
+		cfg.fingerprint, cfg.provider, cfg.fpSize = nil, provider, size
+	}
+}
+
+// WithReader sets the reader for providing random bytes, it's required.
+func WithReader(rndRd io.Reader) Option {
+	return func(cfg *generatorConfig) {
+		cfg.rndRd = rndRd
+	}
+}
+
+// WithStrategy sets a monotonic strategy, to set logic for payload mutation.
+// Generator is non-monotonic by default.
+func WithStrategy(strategy p.CcIdMonotonicStrategy) Option {
+	return func(cfg *generatorConfig) {
+		cfg.strategy = strategy
+	}
+}
+
+// WithClock sets the clock used by Next, p.RealClock by default.
+func WithClock(clock p.Clock) Option {
+	return func(cfg *generatorConfig) {
+		cfg.clock = clock
+	}
+}
+
+// WithEpoch sets the start of timestamps, e.g. p.UnixEpoch. p.DefaultEpoch by default.
+// Warning: CcIds don't keep the epoch, so their Time(), p.Compare with other sizes and p.TimeRange are wrong
+// for a custom epoch. Use p.TimeWithEpoch, or parse CcIds with FromStringWithEpoch to rebase them to p.DefaultEpoch.
+func WithEpoch(epoch time.Time) Option {
+	return func(cfg *generatorConfig) {
+		cfg.epoch = epoch
+	}
+}
+
+// WithMillisecond switches generator to millisecond CcIds: CcId96Ms, CcId128Ms or CcId160Ms.
+func WithMillisecond() Option {
+	return func(cfg *generatorConfig) {
+		cfg.millisecond = true
+	}
+}
+
+// WithRangePolicy sets behavior for time out of timestamp range, p.TimestampRangeError by default.
+func WithRangePolicy(policy p.TimestampRangePolicy) Option {
+	return func(cfg *generatorConfig) {
+		cfg.rangePolicy = policy
+	}
+}
+
+// WithClockPolicy sets behavior of a monotonic generator when the clock moves backwards, ClockTolerate by default.
+func WithClockPolicy(policy ClockPolicy) Option {
+	return func(cfg *generatorConfig) {
+		cfg.clockPolicy = policy
+	}
+}
+
+// WithMaxDrift limits how far timestamp of a monotonic generator can be ahead of the clock,
+// after clock regression or payload overflow. ClockDriftExceededError is returned for larger drift.
+// Zero means unlimited drift, it's the default. It can't be less than the lease of WithStateStore.
+func WithMaxDrift(d time.Duration) Option {
+	return func(cfg *generatorConfig) {
+		cfg.maxDrift = d
+	}
+}
+
+// WithSleep sets the function used for waiting by ClockBlock policy and NextContext, it's useful for tests with a mocked clock.
+// By default, generator waits with a timer interrupted by context cancellation, nil restores the default.
+func WithSleep(sleep func(d time.Duration)) Option {
+	return func(cfg *generatorConfig) {
+		cfg.sleep = sleep
+	}
+}
+
+// WithStateStore makes a monotonic generator persistent, e.g. with NewFileStateStore.
+// The state is restored by NewGenerator, so CcIds stay monotonic across process restarts.
+// A high-water mark 'lease' ahead of the current timestamp is saved whenever generator reaches the previous one,
+// so the store is written at most once per lease and the state is safe after a crash.
+// Call Close on shutdown to save the exact state. 'lease' must be positive, it's rounded down to timestamp precision.
+// 'lease' can't exceed WithMaxDrift, the state restored after a crash is up to a lease ahead of the clock.
+func WithStateStore(store StateStore, lease time.Duration) Option {
+	return func(cfg *generatorConfig) {
+		cfg.store = store
+		cfg.lease = lease
+	}
+}
+
+// WithMetrics sets the receiver of generator events, e.g. NewCounters or NewExpvarCounters. No metrics by default.
+func WithMetrics(metrics Metrics) Option {
+	return func(cfg *generatorConfig) {
+		cfg.metrics = metrics
+	}
+}
+
+// NewGenerator creates a new CcId Generator configured by options.
+// WithSize and WithReader are required, all options are validated up front:
+// p.InvalidLengthError is returned for unsupported size, p.InvalidFingerprintSizeError for too large fingerprint
+// and InvalidOptionError for a missing reader, nil clock or metrics, unknown policy, negative drift
+// or a state store without monotonic strategy. Errors of the state store are returned as is.
+func NewGenerator(opts ...Option) (CcIdGen, error) {
+	cfg, err := newGeneratorConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	gen := newGenerator(cfg)
+	err = gen.restore()
+	if err != nil {
+		return nil, err
+	}
+	return gen, nil
+}
+
+func newGeneratorConfig(opts []Option) (generatorConfig, error) {
+	cfg, err := applyOptions(opts)
+	if err != nil {
+		return cfg, err
+	}
+	return cfg, cfg.validate()
+}
+
+// applyOptions applies 'opts' to the default config and resolves the fingerprint provider, the config isn't validated.
+func applyOptions(opts []Option) (generatorConfig, error) {
+	cfg := generatorConfig{clock: p.RealClock{}, metrics: noopMetrics{}}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.provider != nil {
+		fp, err := cfg.provider.Fingerprint(cfg.fpSize)
+		if err != nil {
+			return cfg, err
+		}
+		cfg.fingerprint = fp
+	}
+	return cfg, nil
+}
+
+func (cfg generatorConfig) validate() error {
+	maxFingerprintSize := byte(p.MaxFingerprintSize)
+	switch cfg.size {
+	case p.ByteSliceSize64:
+		if cfg.millisecond {
+			return p.InvalidLengthError(cfg.size)
+		}
+		maxFingerprintSize = p.MaxFingerprintSize64
+	case p.ByteSliceSize96, p.ByteSliceSize128, p.ByteSliceSize160:
+	default:
+		return p.InvalidLengthError(cfg.size)
+	}
+	if len(cfg.fingerprint) > int(maxFingerprintSize) {
+		return p.InvalidFingerprintSizeError{
+			ProvidedSize: byte(len(cfg.fingerprint)),
+			RequiredSize: maxFingerprintSize,
+		}
+	}
+	if cfg.rndRd == nil {
+		return InvalidOptionError("WithReader")
+	}
+	if cfg.clock == nil {
+		return InvalidOptionError("WithClock")
+	}
+	if cfg.metrics == nil {
+		return InvalidOptionError("WithMetrics")
+	}
+	if cfg.rangePolicy > p.TimestampRangeWrap {
+		return InvalidOptionError("WithRangePolicy")
+	}
+	if cfg.clockPolicy > ClockError {
+		return InvalidOptionError("WithClockPolicy")
+	}
+	if cfg.maxDrift < 0 {
+		return InvalidOptionError("WithMaxDrift")
+	}
+	if cfg.store != nil && (cfg.strategy == nil || cfg.lease <= 0) {
+		return InvalidOptionError("WithStateStore")
+	}
+	// the restored high-water mark can be a lease ahead of the clock after a crash
+	if cfg.store != nil && cfg.maxDrift != 0 && cfg.lease > cfg.maxDrift {
+		return InvalidOptionError("WithStateStore")
+	}
+	return nil
+}
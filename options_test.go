@@ -0,0 +1,93 @@
+package ccid_go
+
+import (
+	"errors"
+	"github.com/Pencroff/ccid_go/fingerprint"
+	p "github.com/Pencroff/ccid_go/pkg"
+	"testing"
+	"time"
+)
+
+func TestNewGenerator(t *testing.T) {
+	mockTime := time.Date(2024, 1, 16, 15, 44, 56, 0, time.UTC)
+	r := &mockStaticReader{Val: 0xA5}
+	gen, err := NewGenerator(WithSize(p.ByteSliceSize96), WithFingerprint([]byte{0x55}), WithReader(r),
+		WithStrategy(p.NewFiftyPercentMonotonicStrategy(r)), WithClock(&mockStaticClock{Val: mockTime}))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	id, err := gen.Next()
+	want := []byte{0x12, 0x34, 0x56, 0x78, 0x55, 0xA5, 0xA5, 0xA5, 0xA5, 0xA5, 0xA5, 0xA5}
+	if err != nil || !p.SliceEqual(id.Bytes(), want) {
+		t.Errorf("Next() = %x, %v, want %x", id.Bytes(), err, want)
+	}
+}
+
+func TestNewGenerator_FingerprintProvider(t *testing.T) {
+	provider := fingerprint.String("instance-1")
+	want, _ := provider.Fingerprint(2)
+	gen, err := NewGenerator(WithSize(p.ByteSliceSize96), WithFingerprint([]byte{0x55}), WithReader(&mockReader{}),
+		WithFingerprintProvider(provider, 2))
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	id, _ := gen.Next()
+	if !p.SliceEqual(id.Fingerprint(), want) {
+		t.Errorf("Fingerprint() = %x, want %x", id.Fingerprint(), want)
+	}
+	var unavailableErr fingerprint.UnavailableError
+	_, err = NewGenerator(WithSize(p.ByteSliceSize96), WithReader(&mockReader{}),
+		WithFingerprintProvider(fingerprint.String(""), 2))
+	if !errors.As(err, &unavailableErr) {
+		t.Errorf("NewGenerator() error = %v, want fingerprint.UnavailableError", err)
+	}
+	var fpErr p.InvalidFingerprintSizeError
+	_, err = NewGenerator(WithSize(p.ByteSliceSize64), WithReader(&mockReader{}),
+		WithFingerprintProvider(provider, 2))
+	if !errors.As(err, &fpErr) {
+		t.Errorf("NewGenerator() error = %v, want InvalidFingerprintSizeError", err)
+	}
+}
+
+func TestNewGenerator_Error(t *testing.T) {
+	r := &mockReader{}
+	var lengthErr p.InvalidLengthError
+	var fpErr p.InvalidFingerprintSizeError
+	var optionErr InvalidOptionError
+	cases := map[string]struct {
+		opts   []Option
+		target any
+	}{
+		"no size":              {[]Option{WithReader(r)}, &lengthErr},
+		"invalid size":         {[]Option{WithSize(10), WithReader(r)}, &lengthErr},
+		"millisecond 64":       {[]Option{WithSize(p.ByteSliceSize64), WithReader(r), WithMillisecond()}, &lengthErr},
+		"large fingerprint 64": {[]Option{WithSize(p.ByteSliceSize64), WithFingerprint([]byte{1, 2}), WithReader(r)}, &fpErr},
+		"large fingerprint":    {[]Option{WithSize(p.ByteSliceSize128), WithFingerprint(make([]byte, 6)), WithReader(r)}, &fpErr},
+		"no reader":            {[]Option{WithSize(p.ByteSliceSize96)}, &optionErr},
+		"nil clock":            {[]Option{WithSize(p.ByteSliceSize96), WithReader(r), WithClock(nil)}, &optionErr},
+		"nil metrics":          {[]Option{WithSize(p.ByteSliceSize96), WithReader(r), WithMetrics(nil)}, &optionErr},
+		"range policy":         {[]Option{WithSize(p.ByteSliceSize96), WithReader(r), WithRangePolicy(10)}, &optionErr},
+		"clock policy":         {[]Option{WithSize(p.ByteSliceSize96), WithReader(r), WithClockPolicy(10)}, &optionErr},
+		"negative drift":       {[]Option{WithSize(p.ByteSliceSize96), WithReader(r), WithMaxDrift(-time.Second)}, &optionErr},
+	}
+	for _, key := range p.SortKeys(cases) {
+		c := cases[key]
+		t.Run(key, func(t *testing.T) {
+			gen, err := NewGenerator(c.opts...)
+			if !errors.As(err, c.target) || gen != nil {
+				t.Errorf("NewGenerator() = %v, %v, want %T", gen, err, c.target)
+			}
+		})
+	}
+	t.Run("wrappers", func(t *testing.T) {
+		if _, err := NewCcIdGen(10, r); !errors.As(err, &lengthErr) {
+			t.Errorf("NewCcIdGen(10) error = %v, want InvalidLengthError", err)
+		}
+		if _, err := NewMonotonicCcIdGenWithFingerprint(p.ByteSliceSize96, make([]byte, 6), r, nil); !errors.As(err, &fpErr) {
+			t.Errorf("NewMonotonicCcIdGenWithFingerprint(6 bytes) error = %v, want InvalidFingerprintSizeError", err)
+		}
+		if _, err := NewMonotonicCcIdGen(p.ByteSliceSize96, nil, nil); !errors.As(err, &optionErr) {
+			t.Errorf("NewMonotonicCcIdGen(nil reader) error = %v, want InvalidOptionError", err)
+		}
+	})
+}
@@ -38,35 +38,59 @@ func EncodeToBase16(b []byte) (string, error) {
 		return "", err
 	}
 	r := [Base16strSize160]byte{}
-	idx := 0
-	for _, v := range b {
-		r[idx] = base16Alphabet[v>>4]
-		r[idx+1] = base16Alphabet[v&0x0f]
-		idx += 2
-	}
+	asBase16(b, r[:size])
 	return string(r[:size]), nil
 }
 
 // DecodeFromBase16 decodes a base16 string to a byte slice.
 // The byte order is big endian.
 func DecodeFromBase16(str string) ([]byte, error) {
-	pointer := byte(len(str))
-	size, err := getBase16byteSliceSize(pointer)
+	size, err := getBase16byteSliceSize(byte(len(str)))
 	if err != nil {
 		return []byte{}, err
 	}
 	res := [ByteSliceSize160]byte{}
-	bytePointer := size - 1
+	err = fromBase16(str, res[:size])
+	if err != nil {
+		return []byte{}, err
+	}
+	return res[:size], nil
+}
+
+// AppendBase16 appends base16 encoding of a byte slice to 'dst' and returns the extended buffer.
+// It doesn't allocate if 'dst' has enough capacity.
+func AppendBase16(dst, b []byte) ([]byte, error) {
+	size, err := getBase16strSize(byte(len(b)))
+	if err != nil {
+		return dst, err
+	}
+	dst, tail := extend(dst, int(size))
+	asBase16(b, tail)
+	return dst, nil
+}
+
+func asBase16(src, dst []byte) {
+	idx := 0
+	for _, v := range src {
+		dst[idx] = base16Alphabet[v>>4]
+		dst[idx+1] = base16Alphabet[v&0x0f]
+		idx += 2
+	}
+}
+
+func fromBase16[S string | []byte](src S, dst []byte) error {
+	pointer := byte(len(src))
+	bytePointer := byte(len(dst)) - 1
 	for i := pointer - 2; i < 250; i -= 2 {
-		vLow := reverseBase16Table[str[i+1]]
-		vHigh := reverseBase16Table[str[i]]
+		vLow := reverseBase16Table[src[i+1]]
+		vHigh := reverseBase16Table[src[i]]
 		if vLow == 0xff || vHigh == 0xff {
-			return []byte{}, InvalidCharacterError{str[i], i}
+			return InvalidCharacterError{src[i], i}
 		}
-		res[bytePointer] = vHigh<<4 | vLow
+		dst[bytePointer] = vHigh<<4 | vLow
 		bytePointer -= 1
 	}
-	return res[:size], nil
+	return nil
 }
 
 func getBase16strSize(l byte) (byte, error) {
@@ -1,6 +1,7 @@
 package pkg
 
 import (
+	"errors"
 	"sort"
 	"strings"
 	"testing"
@@ -96,3 +97,20 @@ func TestBase16Decode_InvalidCharacterError(t *testing.T) {
 		}
 	}
 }
+
+func TestBase16Append(t *testing.T) {
+	keys := SortKeys(testCaseEncodeDecodeMap)
+	for _, name := range keys {
+		tc := testCaseEncodeDecodeMap[name]
+		t.Run(name, func(t *testing.T) {
+			got, err := AppendBase16([]byte("id:"), tc.data)
+			if err != nil || string(got) != "id:"+tc.base16 {
+				t.Errorf("AppendBase16(%v) = '%s', %v, want 'id:%s'", tc.data, got, err, tc.base16)
+			}
+		})
+	}
+	got, err := AppendBase16([]byte("id:"), make([]byte, 7))
+	if string(got) != "id:" || !errors.As(err, new(InvalidLengthError)) {
+		t.Errorf("AppendBase16() = '%s', %v, want InvalidLengthError", got, err)
+	}
+}
@@ -31,6 +31,25 @@ var (
 		"\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff" +
 		"\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff" +
 		"\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff"
+
+	// lenientBase32Table maps Crockford base32 characters, lowercase letters and aliases to characters of base32Alphabet
+	lenientBase32Table = "" +
+		"\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff" +
+		"\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff" +
+		"\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff" +
+		"\x30\x31\x32\x33\x34\x35\x36\x37\x38\x39\xff\xff\xff\xff\xff\xff" +
+		"\xff\x41\x42\x43\x44\x45\x46\x47\x48\x31\x4a\x4b\x31\x4d\x4e\x30" +
+		"\x50\x51\x52\x53\x54\xff\x56\x57\x58\x59\x5a\xff\xff\xff\xff\xff" +
+		"\xff\x41\x42\x43\x44\x45\x46\x47\x48\x31\x4a\x4b\x31\x4d\x4e\x30" +
+		"\x50\x51\x52\x53\x54\xff\x56\x57\x58\x59\x5a\xff\xff\xff\xff\xff" +
+		"\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff" +
+		"\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff" +
+		"\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff" +
+		"\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff" +
+		"\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff" +
+		"\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff" +
+		"\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff" +
+		"\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff"
 )
 
 // EncodeToBase32 encodes a byte slice to a base32 string.
@@ -55,13 +74,78 @@ func DecodeFromBase32(str string) ([]byte, error) {
 		return []byte{}, err
 	}
 	res := make([]byte, size)
-	err = fromBase32([]byte(str), res)
+	err = fromBase32(str, res)
+	if err != nil {
+		return []byte{}, err
+	}
+	return res, nil
+}
+
+// AppendBase32 appends base32 encoding of a byte slice to 'dst' and returns the extended buffer.
+// It doesn't allocate if 'dst' has enough capacity.
+func AppendBase32(dst, b []byte) ([]byte, error) {
+	size, err := getBase32strSize(byte(len(b)))
+	if err != nil {
+		return dst, err
+	}
+	dst, tail := extend(dst, int(size))
+	asBase32(b, tail)
+	return dst, nil
+}
+
+// NormalizeBase32 converts a Crockford base32 string to the canonical form accepted by DecodeFromBase32.
+// Lowercase letters are converted to uppercase, 'O' is read as '0', 'I' and 'L' as '1', hyphens are removed.
+// It returns InvalidCharacterError with position in 's' for other characters,
+// and InputTooLongError if 's' is longer than MaxInputLength characters, length is not validated otherwise.
+func NormalizeBase32(s string) (string, error) {
+	res, err := appendNormalizedBase32(make([]byte, 0, len(s)), s)
+	if err != nil {
+		return "", err
+	}
+	return string(res), nil
+}
+
+// DecodeFromBase32Lenient decodes a Crockford base32 string to a byte slice, see NormalizeBase32.
+// Use DecodeFromBase32 for strict decoding of the canonical form.
+func DecodeFromBase32Lenient(str string) ([]byte, error) {
+	buf := [Base32strSize160]byte{}
+	normalized, err := appendNormalizedBase32(buf[:0], str)
+	if err != nil {
+		return []byte{}, err
+	}
+	if len(normalized) > Base32strSize160 {
+		return []byte{}, InvalidLengthError(byte(len(normalized)))
+	}
+	size, err := getBase32byteSliceSize(byte(len(normalized)))
+	if err != nil {
+		return []byte{}, err
+	}
+	res := make([]byte, size)
+	err = fromBase32(normalized, res)
 	if err != nil {
 		return []byte{}, err
 	}
 	return res, nil
 }
 
+func appendNormalizedBase32(dst []byte, src string) ([]byte, error) {
+	if len(src) > MaxInputLength {
+		return dst, InputTooLongError(len(src))
+	}
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+		if c == '-' {
+			continue
+		}
+		v := lenientBase32Table[c]
+		if v == 0xff {
+			return dst, InvalidCharacterError{c, uint8(i)}
+		}
+		dst = append(dst, v)
+	}
+	return dst, nil
+}
+
 // byte		0       1       2       3       4
 // bit 		1111111111111111111111111111111111111111
 // char		|0  ||1  ||2  ||3  ||4  ||5  ||6  ||7  |
@@ -129,7 +213,7 @@ func asBase32(src, dst []byte) {
 	}
 }
 
-func fromBase32(src, dst []byte) error {
+func fromBase32[S string | []byte](src S, dst []byte) error {
 	pointer := byte(len(src))
 	bytePointer := byte(len(dst))
 	buf := [Base32strSize160]byte{}
@@ -1,6 +1,7 @@
 package pkg
 
 import (
+	"errors"
 	"sort"
 	"strings"
 	"testing"
@@ -134,3 +135,89 @@ func TestDecodeBase32_OverflowError(t *testing.T) {
 		})
 	}
 }
+
+func TestBase32Append(t *testing.T) {
+	keys := SortKeys(testCaseEncodeDecodeMap)
+	for _, name := range keys {
+		tc := testCaseEncodeDecodeMap[name]
+		t.Run(name, func(t *testing.T) {
+			got, err := AppendBase32([]byte("id:"), tc.data)
+			if err != nil || string(got) != "id:"+tc.base32 {
+				t.Errorf("AppendBase32(%v) = '%s', %v, want 'id:%s'", tc.data, got, err, tc.base32)
+			}
+		})
+	}
+	got, err := AppendBase32([]byte("id:"), make([]byte, 7))
+	if string(got) != "id:" || !errors.As(err, new(InvalidLengthError)) {
+		t.Errorf("AppendBase32() = '%s', %v, want InvalidLengthError", got, err)
+	}
+}
+
+func TestNormalizeBase32(t *testing.T) {
+	cases := map[string]struct {
+		src  string
+		want string
+		err  error
+	}{
+		"canonical":   {"0123456789ABCDEFGHJKMNPQRSTVWXYZ", "0123456789ABCDEFGHJKMNPQRSTVWXYZ", nil},
+		"lowercase":   {"0123456789abcdefghjkmnpqrstvwxyz", "0123456789ABCDEFGHJKMNPQRSTVWXYZ", nil},
+		"aliases":     {"OoIiLl", "001111", nil},
+		"hyphens":     {"-01AB-CD-", "01ABCD", nil},
+		"empty":       {"", "", nil},
+		"u":           {"01U", "", InvalidCharacterError{'U', 2}},
+		"lowercase u": {"01-u", "", InvalidCharacterError{'u', 3}},
+		"space":       {"01 AB", "", InvalidCharacterError{' ', 2}},
+		"max length":  {strings.Repeat("-", MaxInputLength-1) + "a", "A", nil},
+		"too long":    {strings.Repeat("-", MaxInputLength) + "a", "", InputTooLongError(MaxInputLength + 1)},
+	}
+	for _, name := range SortKeys(cases) {
+		tc := cases[name]
+		t.Run(name, func(t *testing.T) {
+			got, err := NormalizeBase32(tc.src)
+			if got != tc.want || err != tc.err {
+				t.Errorf("NormalizeBase32(%q) = %q, %v, want %q, %v", tc.src, got, err, tc.want, tc.err)
+			}
+		})
+	}
+}
+
+func TestBase32DecodeLenient(t *testing.T) {
+	keys := SortKeys(testCaseEncodeDecodeMap)
+	for _, name := range keys {
+		tc := testCaseEncodeDecodeMap[name]
+		t.Run(name, func(t *testing.T) {
+			src := strings.NewReplacer("0", "o", "1", "l").Replace(strings.ToLower(tc.base32))
+			src = src[:4] + "-" + src[4:]
+			got, err := DecodeFromBase32Lenient(src)
+			if err != nil || !SliceEqual(got, tc.data) {
+				t.Errorf("DecodeFromBase32Lenient(%v) =\n%x, %v, want\n%x", src, got, err, tc.data)
+			}
+			_, err = DecodeFromBase32(src)
+			if err == nil {
+				t.Errorf("DecodeFromBase32(%v) error = nil, want strict decoding error", src)
+			}
+		})
+	}
+}
+
+func TestBase32DecodeLenient_Error(t *testing.T) {
+	cases := map[string]struct {
+		src string
+		err error
+	}{
+		"character": {"0000-0000-U", InvalidCharacterError{'U', 10}},
+		"length":    {"0000-0000", InvalidLengthError(8)},
+		"long":      {strings.Repeat("0", 40), InvalidLengthError(40)},
+		"too long":  {strings.Repeat("0", 276), InputTooLongError(276)},
+		"overflow":  {"zzzz-zzzz-zzzz-z", OverflowError(ByteSliceSize64)},
+	}
+	for _, name := range SortKeys(cases) {
+		tc := cases[name]
+		t.Run(name, func(t *testing.T) {
+			got, err := DecodeFromBase32Lenient(tc.src)
+			if len(got) != 0 || err != tc.err {
+				t.Errorf("DecodeFromBase32Lenient(%q) = %x, %v, want %v", tc.src, got, err, tc.err)
+			}
+		})
+	}
+}
@@ -57,7 +57,7 @@ func DecodeFromBase62(str string) ([]byte, error) {
 		return []byte{}, err
 	}
 	res := make([]byte, size)
-	err = fromBase62([]byte(str), res)
+	err = fromBase62(str, res)
 	if err != nil {
 		return []byte{}, err
 	}
@@ -65,6 +65,18 @@ func DecodeFromBase62(str string) ([]byte, error) {
 
 }
 
+// AppendBase62 appends base62 encoding of a byte slice to 'dst' and returns the extended buffer.
+// It doesn't allocate if 'dst' has enough capacity.
+func AppendBase62(dst, b []byte) ([]byte, error) {
+	size, err := getBase62strSize(byte(len(b)))
+	if err != nil {
+		return dst, err
+	}
+	dst, tail := extend(dst, int(size))
+	asBase62(b, tail, base62Alphabet)
+	return dst, nil
+}
+
 func asBase62(src, dst []byte, alphabet string) {
 	const dstBase = 62 // len(alphabet)
 	bytePointer := len(src)
@@ -102,7 +114,7 @@ func asBase62(src, dst []byte, alphabet string) {
 	copy(dst[:pointer], zeroString)
 }
 
-func fromBase62(src, dst []byte) error {
+func fromBase62[S string | []byte](src S, dst []byte) error {
 	const srcBase = 62
 	const dstBase = 1 << 32 // 4294967296 // 2^32
 	const dstMask = dstBase - 1
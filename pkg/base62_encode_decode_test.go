@@ -1,6 +1,7 @@
 package pkg
 
 import (
+	"errors"
 	"sort"
 	"strings"
 	"testing"
@@ -109,3 +110,20 @@ func TestDecodeBase62_OverflowError(t *testing.T) {
 		})
 	}
 }
+
+func TestBase62Append(t *testing.T) {
+	keys := SortKeys(testCaseEncodeDecodeMap)
+	for _, name := range keys {
+		tc := testCaseEncodeDecodeMap[name]
+		t.Run(name, func(t *testing.T) {
+			got, err := AppendBase62([]byte("id:"), tc.data)
+			if err != nil || string(got) != "id:"+tc.base62 {
+				t.Errorf("AppendBase62(%v) = '%s', %v, want 'id:%s'", tc.data, got, err, tc.base62)
+			}
+		})
+	}
+	got, err := AppendBase62([]byte("id:"), make([]byte, 7))
+	if string(got) != "id:" || !errors.As(err, new(InvalidLengthError)) {
+		t.Errorf("AppendBase62() = '%s', %v, want InvalidLengthError", got, err)
+	}
+}
@@ -162,3 +162,47 @@ func BenchmarkDecodeBase62(b *testing.B) {
 		fmt.Printf("---\n")
 	}
 }
+
+func BenchmarkAppendBase(b *testing.B) {
+	for _, tcName := range testList {
+		tc := testCaseEncodeDecodeMap[tcName].data
+		size := len(tc)
+		dst := make([]byte, 0, Base16strSize160)
+		encoders := map[string]func(dst, b []byte) ([]byte, error){
+			"62": AppendBase62,
+			"32": AppendBase32,
+			"16": AppendBase16,
+		}
+		for _, base := range SortKeys(encoders) {
+			encode := encoders[base]
+			b.Run(tcName+"_base"+base, func(bb *testing.B) {
+				bb.ReportAllocs()
+				for i := 0; i < bb.N; i++ {
+					dst, _ = encode(dst[:0], tc)
+				}
+				bb.SetBytes(int64(size))
+			})
+		}
+		fmt.Printf("---\n")
+	}
+}
+
+func BenchmarkParseInto(b *testing.B) {
+	tc := TestCaseCcId160Map["some id fingerprint"]
+	sources := map[string][]byte{
+		"62": []byte(tc.Base62),
+		"32": []byte(tc.Base32),
+		"16": []byte(tc.Base16),
+	}
+	var dst CcId160
+	for _, base := range SortKeys(sources) {
+		src := sources[base]
+		b.Run("base"+base, func(bb *testing.B) {
+			bb.ReportAllocs()
+			for i := 0; i < bb.N; i++ {
+				_ = ParseInto(&dst, src)
+			}
+			bb.SetBytes(ByteSliceSize160)
+		})
+	}
+}
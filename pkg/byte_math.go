@@ -42,3 +42,21 @@ func Add8BigEndian(a, b []byte, carryIn byte) (sum []byte, carryOut byte) {
 	}
 	return
 }
+
+// Uint48BigEndian returns 48 bits unsigned integer from 6 bytes in big endian order.
+func Uint48BigEndian(b []byte) uint64 {
+	_ = b[5] // bounds check hint to compiler
+	return uint64(b[5]) | uint64(b[4])<<8 | uint64(b[3])<<16 | uint64(b[2])<<24 |
+		uint64(b[1])<<32 | uint64(b[0])<<40
+}
+
+// PutUint48BigEndian puts lower 48 bits of 'v' into 6 bytes in big endian order.
+func PutUint48BigEndian(b []byte, v uint64) {
+	_ = b[5] // bounds check hint to compiler
+	b[0] = byte(v >> 40)
+	b[1] = byte(v >> 32)
+	b[2] = byte(v >> 24)
+	b[3] = byte(v >> 16)
+	b[4] = byte(v >> 8)
+	b[5] = byte(v)
+}
@@ -29,3 +29,27 @@ func TestAdd8Slice(t *testing.T) {
 		})
 	}
 }
+
+func TestUint48BigEndian(t *testing.T) {
+	cases := map[string]struct {
+		v uint64
+		b []byte
+	}{
+		"zero": {0, []byte{0, 0, 0, 0, 0, 0}},
+		"some": {0x0123456789ab, []byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xab}},
+		"max":  {0xffffffffffff, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+	}
+	for _, key := range SortKeys(cases) {
+		tc := cases[key]
+		t.Run(key, func(t *testing.T) {
+			if got := Uint48BigEndian(tc.b); got != tc.v {
+				t.Errorf("Uint48BigEndian(%x) = %x, want %x", tc.b, got, tc.v)
+			}
+			b := make([]byte, 6)
+			PutUint48BigEndian(b, tc.v|0xffff<<48)
+			if !SliceEqual(b, tc.b) {
+				t.Errorf("PutUint48BigEndian(%x) = %x, want %x", tc.v, b, tc.b)
+			}
+		})
+	}
+}
@@ -65,7 +65,30 @@ func (id CcId128) AsBase16() string {
 	return v
 }
 
+// AppendBase62 appends the CcId128 as a base62 string to 'dst' and returns the extended buffer.
+func (id CcId128) AppendBase62(dst []byte) []byte {
+	dst, _ = AppendBase62(dst, id.data[:])
+	return dst
+}
+
+// AppendBase32 appends the CcId128 as a base32 string to 'dst' and returns the extended buffer.
+func (id CcId128) AppendBase32(dst []byte) []byte {
+	dst, _ = AppendBase32(dst, id.data[:])
+	return dst
+}
+
+// AppendBase16 appends the CcId128 as a base16 string to 'dst' and returns the extended buffer.
+func (id CcId128) AppendBase16(dst []byte) []byte {
+	dst, _ = AppendBase16(dst, id.data[:])
+	return dst
+}
+
 func NewCcId128WithFingerprint(timestamp uint32, fingerprint []byte, payload []byte) (CcId, error) {
+	return NewCcId128Value(timestamp, fingerprint, payload)
+}
+
+// NewCcId128Value is NewCcId128WithFingerprint returning CcId128 value instead of CcId interface, so it doesn't allocate.
+func NewCcId128Value(timestamp uint32, fingerprint []byte, payload []byte) (CcId128, error) {
 	var id CcId128
 	payloadSize := byte(len(payload))
 	fingerprintSize := byte(len(fingerprint))
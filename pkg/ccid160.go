@@ -65,7 +65,30 @@ func (id CcId160) AsBase16() string {
 	return v
 }
 
+// AppendBase62 appends the CcId160 as a base62 string to 'dst' and returns the extended buffer.
+func (id CcId160) AppendBase62(dst []byte) []byte {
+	dst, _ = AppendBase62(dst, id.data[:])
+	return dst
+}
+
+// AppendBase32 appends the CcId160 as a base32 string to 'dst' and returns the extended buffer.
+func (id CcId160) AppendBase32(dst []byte) []byte {
+	dst, _ = AppendBase32(dst, id.data[:])
+	return dst
+}
+
+// AppendBase16 appends the CcId160 as a base16 string to 'dst' and returns the extended buffer.
+func (id CcId160) AppendBase16(dst []byte) []byte {
+	dst, _ = AppendBase16(dst, id.data[:])
+	return dst
+}
+
 func NewCcId160WithFingerprint(timestamp uint32, fingerprint []byte, payload []byte) (CcId, error) {
+	return NewCcId160Value(timestamp, fingerprint, payload)
+}
+
+// NewCcId160Value is NewCcId160WithFingerprint returning CcId160 value instead of CcId interface, so it doesn't allocate.
+func NewCcId160Value(timestamp uint32, fingerprint []byte, payload []byte) (CcId160, error) {
 	var id CcId160
 	payloadSize := byte(len(payload))
 	fingerprintSize := byte(len(fingerprint))
@@ -0,0 +1,135 @@
+package pkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestNewCcId160Ms(t *testing.T) {
+	keys := SortKeys(TestCaseCcId160MsMap)
+	for _, key := range keys {
+		tc := TestCaseCcId160MsMap[key]
+		t.Run(key, func(t *testing.T) {
+			got, err := NewCcId160MsWithFingerprint(tc.timestamp, tc.Fingerprint, tc.payload)
+			if err != nil {
+				t.Fatalf("NewCcId160Ms(%x, %x, %x) error = %v", tc.timestamp, tc.Fingerprint, tc.payload, err)
+			}
+			if got.Size() != ByteSliceSize160 {
+				t.Errorf("Size:\nNewCcId160Ms(%x, %x, %x) =\n%d, want\n%d",
+					tc.timestamp, tc.Fingerprint, tc.payload, got.Size(), ByteSliceSize160)
+			}
+			if v := got.(CcId160Ms).TimestampMs(); v != tc.timestamp {
+				t.Errorf("TimestampMs:\nNewCcId160Ms(%x, %x, %x) =\n%d, want\n%d",
+					tc.timestamp, tc.Fingerprint, tc.payload, v, tc.timestamp)
+			}
+			if v := got.Timestamp(); v != uint32(tc.timestamp/1000) {
+				t.Errorf("Timestamp:\nNewCcId160Ms(%x, %x, %x) =\n%d, want\n%d",
+					tc.timestamp, tc.Fingerprint, tc.payload, v, tc.timestamp/1000)
+			}
+			if !got.Time().Equal(tc.time) {
+				t.Errorf("Time:\nNewCcId160Ms(%x, %x, %x) =\n%s, want\n%s",
+					tc.timestamp, tc.Fingerprint, tc.payload, got.Time().Format(RFC3339Milli), tc.time.Format(RFC3339Milli))
+			}
+			if !SliceEqual(got.Fingerprint(), tc.Fingerprint) {
+				t.Errorf("Fingerprint:\nNewCcId160Ms(%x, %x, %x) =\n%x, want\n%x",
+					tc.timestamp, tc.Fingerprint, tc.payload, got.Fingerprint(), tc.Fingerprint)
+			}
+			if !SliceEqual(got.Payload(), tc.payload) {
+				t.Errorf("Payload:\nNewCcId160Ms(%x, %x, %x) =\n%x, want\n%x",
+					tc.timestamp, tc.Fingerprint, tc.payload, got.Payload(), tc.payload)
+			}
+			if !SliceEqual(got.Bytes(), tc.Bytes) {
+				t.Errorf("Bytes:\nNewCcId160Ms(%x, %x, %x) =\n%x, want\n%x",
+					tc.timestamp, tc.Fingerprint, tc.payload, got.Bytes(), tc.Bytes)
+			}
+		})
+		t.Run(key+"_as_base", func(t *testing.T) {
+			got, _ := NewCcId160MsWithFingerprint(tc.timestamp, tc.Fingerprint, tc.payload)
+			if got.String() != tc.Base62 {
+				t.Errorf("String:\nNewCcId160Ms(%x, %x, %x) =\n%s, want\n%s",
+					tc.timestamp, tc.Fingerprint, tc.payload, got.String(), tc.Base62)
+			}
+			if got.AsBase32() != tc.Base32 {
+				t.Errorf("AsBase32:\nNewCcId160Ms(%x, %x, %x) =\n%s, want\n%s",
+					tc.timestamp, tc.Fingerprint, tc.payload, got.AsBase32(), tc.Base32)
+			}
+			if got.AsBase16() != tc.Base16 {
+				t.Errorf("AsBase16:\nNewCcId160Ms(%x, %x, %x) =\n%s, want\n%s",
+					tc.timestamp, tc.Fingerprint, tc.payload, got.AsBase16(), tc.Base16)
+			}
+		})
+		t.Run(key+"_go_string", func(t *testing.T) {
+			got, _ := NewCcId160MsWithFingerprint(tc.timestamp, tc.Fingerprint, tc.payload)
+			v := fmt.Sprintf("%#v", got)
+			if v != tc.GoString {
+				t.Errorf("GoString:\nNewCcId160Ms(%x, %x, %x) =\n%s, want\n%s",
+					tc.timestamp, tc.Fingerprint, tc.payload, v, tc.GoString)
+			}
+		})
+	}
+}
+
+func TestNewCcId160MsError(t *testing.T) {
+	cases := map[string]struct {
+		fingerprint []byte
+		payload     []byte
+		errMsg      string
+	}{
+		"small payload error": {
+			payload: []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13},
+			errMsg:  "CCID: invalid payload length 13 bytes, required min 14 bytes",
+		},
+		"finger print error": {
+			fingerprint: []byte{1, 2, 3, 4, 5, 6},
+			payload:     []byte{1, 2, 3, 4, 5, 6},
+			errMsg:      "CCID: invalid fingerprint length 6 bytes, required max 5 bytes",
+		},
+	}
+	for _, key := range SortKeys(cases) {
+		tc := cases[key]
+		t.Run(key, func(t *testing.T) {
+			got, err := NewCcId160MsWithFingerprint(0x0123456789ab, tc.fingerprint, tc.payload)
+			if err == nil || err.Error() != tc.errMsg {
+				t.Errorf("NewCcId160Ms(%x, %x) error = %v, want %s", tc.fingerprint, tc.payload, err, tc.errMsg)
+			}
+			if got != NilCcId160Ms {
+				t.Errorf("NewCcId160Ms(%x, %x) = %#v, want NilCcId160Ms", tc.fingerprint, tc.payload, got)
+			}
+		})
+	}
+}
+
+func TestCcId160MsMarshalling(t *testing.T) {
+	keys := SortKeys(TestCaseCcId160MsMap)
+	for _, key := range keys {
+		tc := TestCaseCcId160MsMap[key]
+		t.Run(key, func(t *testing.T) {
+			v, _ := NewCcId160MsWithFingerprint(tc.timestamp, tc.Fingerprint, tc.payload)
+			id := v.(CcId160Ms)
+			text, _ := id.MarshalText()
+			var fromText CcId160Ms
+			if err := fromText.UnmarshalText(text); err != nil || !SliceEqual(fromText.Bytes(), tc.Bytes) {
+				t.Errorf("UnmarshalText(%s) = %#v, %v, want %s", text, fromText, err, tc.Base16)
+			}
+			data, _ := id.MarshalBinary()
+			var fromBinary CcId160Ms
+			if err := fromBinary.UnmarshalBinary(data); err != nil || fromBinary != id {
+				t.Errorf("UnmarshalBinary(%x) = %#v, %v, want %s", data, fromBinary, err, tc.GoString)
+			}
+			var seconds CcId160
+			if err := seconds.UnmarshalBinary(data); err == nil {
+				t.Errorf("CcId160.UnmarshalBinary(%x) error = nil, want InvalidHeaderError", data)
+			}
+			js, _ := json.Marshal(id)
+			var fromJSON CcId160Ms
+			if err := json.Unmarshal(js, &fromJSON); err != nil || !SliceEqual(fromJSON.Bytes(), tc.Bytes) {
+				t.Errorf("UnmarshalJSON(%s) = %#v, %v, want %s", js, fromJSON, err, tc.Base16)
+			}
+			var fromSQL CcId160Ms
+			if err := fromSQL.Scan(tc.Base32); err != nil || !SliceEqual(fromSQL.Bytes(), tc.Bytes) {
+				t.Errorf("Scan(%s) = %#v, %v, want %s", tc.Base32, fromSQL, err, tc.Base16)
+			}
+		})
+	}
+}
@@ -65,11 +65,34 @@ func (id CcId64) AsBase16() string {
 	return v
 }
 
+// AppendBase62 appends the CcId64 as a base62 string to 'dst' and returns the extended buffer.
+func (id CcId64) AppendBase62(dst []byte) []byte {
+	dst, _ = AppendBase62(dst, id.data[:])
+	return dst
+}
+
+// AppendBase32 appends the CcId64 as a base32 string to 'dst' and returns the extended buffer.
+func (id CcId64) AppendBase32(dst []byte) []byte {
+	dst, _ = AppendBase32(dst, id.data[:])
+	return dst
+}
+
+// AppendBase16 appends the CcId64 as a base16 string to 'dst' and returns the extended buffer.
+func (id CcId64) AppendBase16(dst []byte) []byte {
+	dst, _ = AppendBase16(dst, id.data[:])
+	return dst
+}
+
 func (id CcId64) Uint64() uint64 {
 	return binary.BigEndian.Uint64(id.data[:])
 }
 
 func NewCcId64WithFingerprint(timestamp uint32, fingerprint []byte, payload []byte) (CcId, error) {
+	return NewCcId64Value(timestamp, fingerprint, payload)
+}
+
+// NewCcId64Value is NewCcId64WithFingerprint returning CcId64 value instead of CcId interface, so it doesn't allocate.
+func NewCcId64Value(timestamp uint32, fingerprint []byte, payload []byte) (CcId64, error) {
 	var id CcId64
 	payloadSize := byte(len(payload))
 	fingerprintSize := byte(len(fingerprint))
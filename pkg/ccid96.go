@@ -65,7 +65,30 @@ func (id CcId96) AsBase16() string {
 	return v
 }
 
+// AppendBase62 appends the CcId96 as a base62 string to 'dst' and returns the extended buffer.
+func (id CcId96) AppendBase62(dst []byte) []byte {
+	dst, _ = AppendBase62(dst, id.data[:])
+	return dst
+}
+
+// AppendBase32 appends the CcId96 as a base32 string to 'dst' and returns the extended buffer.
+func (id CcId96) AppendBase32(dst []byte) []byte {
+	dst, _ = AppendBase32(dst, id.data[:])
+	return dst
+}
+
+// AppendBase16 appends the CcId96 as a base16 string to 'dst' and returns the extended buffer.
+func (id CcId96) AppendBase16(dst []byte) []byte {
+	dst, _ = AppendBase16(dst, id.data[:])
+	return dst
+}
+
 func NewCcId96WithFingerprint(timestamp uint32, fingerprint []byte, payload []byte) (CcId, error) {
+	return NewCcId96Value(timestamp, fingerprint, payload)
+}
+
+// NewCcId96Value is NewCcId96WithFingerprint returning CcId96 value instead of CcId interface, so it doesn't allocate.
+func NewCcId96Value(timestamp uint32, fingerprint []byte, payload []byte) (CcId96, error) {
 	var id CcId96
 	payloadSize := byte(len(payload))
 	fingerprintSize := byte(len(fingerprint))
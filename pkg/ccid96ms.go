@@ -0,0 +1,125 @@
+package pkg
+
+import (
+	"fmt"
+	"time"
+)
+
+var NilCcId96Ms CcId96Ms
+
+type CcId96Ms struct {
+	fingerprintSize byte
+	data            [ByteSliceSize96]byte
+}
+
+func (id CcId96Ms) Size() byte {
+	return ByteSliceSize96
+}
+
+func (id CcId96Ms) Time() time.Time {
+	return ToStandardizedTimeMs(id.TimestampMs())
+}
+
+func (id CcId96Ms) Timestamp() uint32 {
+	return uint32(id.TimestampMs() / 1000)
+}
+
+func (id CcId96Ms) TimestampMs() uint64 {
+	return Uint48BigEndian(id.data[:TimestampMsSize])
+}
+
+func (id CcId96Ms) Fingerprint() []byte {
+	return id.data[TimestampMsSize : TimestampMsSize+id.fingerprintSize]
+}
+
+func (id CcId96Ms) Payload() []byte {
+	return id.data[TimestampMsSize+id.fingerprintSize:]
+}
+
+func (id CcId96Ms) String() string {
+	return id.AsBase62()
+}
+
+func (id CcId96Ms) GoString() string {
+	if id.fingerprintSize > 0 {
+		return fmt.Sprintf("CcIdMs{size: %d, timestamp: %d (%s), fingerprint: 0x%x, payload: 0x%x}",
+			id.Size(), id.TimestampMs(), id.Time().Format(RFC3339Milli), id.Fingerprint(), id.Payload())
+	}
+	return fmt.Sprintf("CcIdMs{size: %d, timestamp: %d (%s), payload: 0x%x}",
+		id.Size(), id.TimestampMs(), id.Time().Format(RFC3339Milli), id.Payload())
+}
+
+func (id CcId96Ms) Bytes() []byte {
+	return id.data[:]
+}
+
+func (id CcId96Ms) AsBase62() string {
+	v, _ := EncodeToBase62(id.data[:])
+	return v
+}
+
+func (id CcId96Ms) AsBase32() string {
+	v, _ := EncodeToBase32(id.data[:])
+	return v
+}
+
+func (id CcId96Ms) AsBase16() string {
+	v, _ := EncodeToBase16(id.data[:])
+	return v
+}
+
+// AppendBase62 appends the CcId96Ms as a base62 string to 'dst' and returns the extended buffer.
+func (id CcId96Ms) AppendBase62(dst []byte) []byte {
+	dst, _ = AppendBase62(dst, id.data[:])
+	return dst
+}
+
+// AppendBase32 appends the CcId96Ms as a base32 string to 'dst' and returns the extended buffer.
+func (id CcId96Ms) AppendBase32(dst []byte) []byte {
+	dst, _ = AppendBase32(dst, id.data[:])
+	return dst
+}
+
+// AppendBase16 appends the CcId96Ms as a base16 string to 'dst' and returns the extended buffer.
+func (id CcId96Ms) AppendBase16(dst []byte) []byte {
+	dst, _ = AppendBase16(dst, id.data[:])
+	return dst
+}
+
+func NewCcId96MsWithFingerprint(timestamp uint64, fingerprint []byte, payload []byte) (CcId, error) {
+	return NewCcId96MsValue(timestamp, fingerprint, payload)
+}
+
+// NewCcId96MsValue is NewCcId96MsWithFingerprint returning CcId96Ms value instead of CcId interface, so it doesn't allocate.
+func NewCcId96MsValue(timestamp uint64, fingerprint []byte, payload []byte) (CcId96Ms, error) {
+	var id CcId96Ms
+	payloadSize := byte(len(payload))
+	fingerprintSize := byte(len(fingerprint))
+	if fingerprintSize > MaxFingerprintSize {
+		return NilCcId96Ms, InvalidFingerprintSizeError{
+			ProvidedSize: fingerprintSize,
+			RequiredSize: MaxFingerprintSize,
+		}
+	}
+	if timestamp > MaxTimestampMs {
+		return NilCcId96Ms, TimestampOutOfRangeError{
+			Time: ToStandardizedTimeMs(timestamp),
+			Min:  ToStandardizedTimeMs(0),
+			Max:  ToStandardizedTimeMs(MaxTimestampMs),
+		}
+	}
+	minPayloadSize := ByteSliceSize96 - TimestampMsSize - fingerprintSize
+	if payloadSize < minPayloadSize {
+		return NilCcId96Ms, InvalidPayloadSizeError{
+			ProvidedSize: payloadSize,
+			RequiredSize: minPayloadSize,
+		}
+	}
+	PutUint48BigEndian(id.data[:TimestampMsSize], timestamp)
+	if fingerprintSize > 0 {
+		id.fingerprintSize = fingerprintSize
+		copy(id.data[TimestampMsSize:TimestampMsSize+fingerprintSize], fingerprint[:])
+	}
+	copy(id.data[TimestampMsSize+id.fingerprintSize:], payload[:])
+	return id, nil
+}
@@ -1,12 +1,22 @@
 package pkg
 
 import (
+	"encoding/binary"
 	"fmt"
+	"slices"
 	"time"
 )
 
 const (
 	TimestampSize = 4
+	// TimestampMsSize is the size of millisecond timestamp of CcId96Ms, CcId128Ms and CcId160Ms.
+	// There is no 64 bit millisecond CcId, it would leave only 2 bytes for fingerprint and payload.
+	TimestampMsSize = 6
+	// MaxTimestampMs is the max millisecond timestamp representable by TimestampMsSize bytes.
+	MaxTimestampMs = 1<<(TimestampMsSize*8) - 1
+
+	// RFC3339Milli is time layout used by GoString of millisecond CcIds.
+	RFC3339Milli = "2006-01-02T15:04:05.000Z07:00"
 
 	MaxFingerprintSize64 = 1
 	MaxFingerprintSize   = 5
@@ -16,6 +26,10 @@ const (
 	ByteSliceSize128 = 16
 	ByteSliceSize160 = 20
 
+	// MaxInputLength is the max length of lenient input, e.g. base32 with hyphens,
+	// so positions of InvalidCharacterError fit in a byte.
+	MaxInputLength = 255
+
 	zeroString = "0000000000000000000000000000000000000000"
 
 	epochStamp int64 = 1400000000
@@ -30,7 +44,8 @@ const (
 type CcId interface {
 	// Size returns the size of the CcId.
 	Size() byte
-	// Time returns the time associated with the CcId.
+	// Time returns the time associated with the CcId, the timestamp is counted from DefaultEpoch.
+	// CcIds don't keep the epoch, for CcIds generated with a custom epoch use TimeWithEpoch or Rebase.
 	Time() time.Time
 	// Timestamp returns the timestamp of the CcId. Unix epoch in seconds.
 	Timestamp() uint32
@@ -52,6 +67,9 @@ type CcId interface {
 
 type CcIdCtor func(timestamp uint32, fingerprint []byte, payload []byte) (CcId, error)
 
+// CcIdMsCtor is a constructor of millisecond CcIds, 'timestamp' is milliseconds from the epoch.
+type CcIdMsCtor func(timestamp uint64, fingerprint []byte, payload []byte) (CcId, error)
+
 type InvalidPayloadSizeError struct {
 	ProvidedSize byte
 	RequiredSize byte
@@ -83,6 +101,56 @@ func (e InvalidLengthError) Error() string {
 	return fmt.Sprintf("CCID: invalid length %d bytes", byte(e))
 }
 
+// InputTooLongError is returned when the length of the input exceeds MaxInputLength characters.
+type InputTooLongError int
+
+func (e InputTooLongError) Error() string {
+	return fmt.Sprintf("CCID: input length %d exceeds max %d characters", int(e), MaxInputLength)
+}
+
+type InvalidBaseError byte
+
+func (e InvalidBaseError) Error() string {
+	return fmt.Sprintf("CCID: unsupported base %d", byte(e))
+}
+
+type InvalidHeaderError byte
+
+func (e InvalidHeaderError) Error() string {
+	return fmt.Sprintf("CCID: invalid header 0x%02x", byte(e))
+}
+
+type InvalidScanTypeError struct {
+	Src any
+}
+
+func (e InvalidScanTypeError) Error() string {
+	return fmt.Sprintf("CCID: unsupported scan, storing %T into CcId", e.Src)
+}
+
+// InvalidTimestampPrecisionError is returned when a second CcId is used where a millisecond one is expected or vice versa.
+// The value is true when a millisecond CcId is expected.
+type InvalidTimestampPrecisionError bool
+
+func (e InvalidTimestampPrecisionError) Error() string {
+	if e {
+		return "CCID: millisecond timestamp precision expected"
+	}
+	return "CCID: second timestamp precision expected"
+}
+
+// TimestampOutOfRangeError is returned when time can't be represented by CcId timestamp.
+type TimestampOutOfRangeError struct {
+	Time time.Time
+	Min  time.Time
+	Max  time.Time
+}
+
+func (e TimestampOutOfRangeError) Error() string {
+	return fmt.Sprintf("CCID: time %s is out of timestamp range [%s, %s]",
+		e.Time.Format(RFC3339Milli), e.Min.Format(RFC3339Milli), e.Max.Format(RFC3339Milli))
+}
+
 type InvalidCharacterError struct {
 	Character byte
 	Pos       uint8
@@ -105,3 +173,166 @@ type RealClock struct{}
 func (RealClock) Now() time.Time {
 	return time.Now()
 }
+
+// ccIdLayout identifies a concrete CcId type by its size and timestamp precision.
+type ccIdLayout struct {
+	size        byte
+	millisecond bool
+}
+
+// extend grows 'dst' by 'n' bytes and returns it with the added tail, it allocates only if capacity is not enough.
+func extend(dst []byte, n int) ([]byte, []byte) {
+	l := len(dst)
+	dst = slices.Grow(dst, n)[:l+n]
+	return dst, dst[l:]
+}
+
+func layoutOf(id CcId) ccIdLayout {
+	_, ok := id.(interface{ TimestampMs() uint64 })
+	return ccIdLayout{size: id.Size(), millisecond: ok}
+}
+
+func (l ccIdLayout) timestampSize() int {
+	if l.millisecond {
+		return TimestampMsSize
+	}
+	return TimestampSize
+}
+
+// fromBytes splits a raw byte slice into timestamp, fingerprint and payload
+// and passes them to the constructor of the layout.
+func (l ccIdLayout) fromBytes(b []byte, fingerprintSize byte) (CcId, error) {
+	if len(b) != int(l.size) {
+		return nil, InvalidLengthError(byte(len(b)))
+	}
+	timestampSize := l.timestampSize()
+	fingerprintEndIdx := timestampSize + int(fingerprintSize)
+	if fingerprintEndIdx > len(b) {
+		return nil, InvalidFingerprintSizeError{
+			ProvidedSize: fingerprintSize,
+			RequiredSize: MaxFingerprintSize,
+		}
+	}
+	return l.ctor(b[:timestampSize], b[timestampSize:fingerprintEndIdx], b[fingerprintEndIdx:])
+}
+
+func (l ccIdLayout) ctor(timestamp []byte, fingerprint []byte, payload []byte) (CcId, error) {
+	if l.millisecond {
+		ctor, err := ccIdMsCtorBySize(l.size)
+		if err != nil {
+			return nil, err
+		}
+		return ctor(Uint48BigEndian(timestamp), fingerprint, payload)
+	}
+	ctor, err := ccIdCtorBySize(l.size)
+	if err != nil {
+		return nil, err
+	}
+	return ctor(binary.BigEndian.Uint32(timestamp), fingerprint, payload)
+}
+
+func (l ccIdLayout) nilCcId() (CcId, error) {
+	if l.millisecond {
+		switch l.size {
+		case ByteSliceSize96:
+			return NilCcId96Ms, nil
+		case ByteSliceSize128:
+			return NilCcId128Ms, nil
+		case ByteSliceSize160:
+			return NilCcId160Ms, nil
+		}
+		return nil, InvalidLengthError(l.size)
+	}
+	return nilCcIdBySize(l.size)
+}
+
+// EncodeToBase encodes a byte slice to a string of the given base.
+// 'base' must be BASE62, BASE32 or BASE16.
+func EncodeToBase(b []byte, base byte) (string, error) {
+	switch base {
+	case BASE62:
+		return EncodeToBase62(b)
+	case BASE32:
+		return EncodeToBase32(b)
+	case BASE16:
+		return EncodeToBase16(b)
+	}
+	return "", InvalidBaseError(base)
+}
+
+// DecodeFromBase decodes a string of the given base to a byte slice.
+// 'base' must be BASE62, BASE32 or BASE16.
+func DecodeFromBase(str string, base byte) ([]byte, error) {
+	switch base {
+	case BASE62:
+		return DecodeFromBase62(str)
+	case BASE32:
+		return DecodeFromBase32(str)
+	case BASE16:
+		return DecodeFromBase16(str)
+	}
+	return []byte{}, InvalidBaseError(base)
+}
+
+// detectBase returns the base of a string by its length for the CcId of the given size.
+// String sizes are unique within the same CcId size.
+func detectBase(strSize int, size byte) (byte, error) {
+	if strSize > 0xff {
+		return 0, InvalidLengthError(byte(strSize))
+	}
+	l := byte(strSize)
+	if v, _ := getBase62byteSliceSize(l); v == size {
+		return BASE62, nil
+	}
+	if v, _ := getBase32byteSliceSize(l); v == size {
+		return BASE32, nil
+	}
+	if v, _ := getBase16byteSliceSize(l); v == size {
+		return BASE16, nil
+	}
+	return 0, InvalidLengthError(l)
+}
+
+func ccIdCtorBySize(size byte) (CcIdCtor, error) {
+	switch size {
+	case ByteSliceSize64:
+		return NewCcId64WithFingerprint, nil
+	case ByteSliceSize96:
+		return NewCcId96WithFingerprint, nil
+	case ByteSliceSize128:
+		return NewCcId128WithFingerprint, nil
+	case ByteSliceSize160:
+		return NewCcId160WithFingerprint, nil
+	}
+	return nil, InvalidLengthError(size)
+}
+
+func ccIdMsCtorBySize(size byte) (CcIdMsCtor, error) {
+	switch size {
+	case ByteSliceSize96:
+		return NewCcId96MsWithFingerprint, nil
+	case ByteSliceSize128:
+		return NewCcId128MsWithFingerprint, nil
+	case ByteSliceSize160:
+		return NewCcId160MsWithFingerprint, nil
+	}
+	return nil, InvalidLengthError(size)
+}
+
+func nilCcIdBySize(size byte) (CcId, error) {
+	switch size {
+	case ByteSliceSize64:
+		return NilCcId64, nil
+	case ByteSliceSize96:
+		return NilCcId96, nil
+	case ByteSliceSize128:
+		return NilCcId128, nil
+	case ByteSliceSize160:
+		return NilCcId160, nil
+	}
+	return nil, InvalidLengthError(size)
+}
+
+func isValidBase(base byte) bool {
+	return base == BASE62 || base == BASE32 || base == BASE16
+}
@@ -0,0 +1,52 @@
+package pkg
+
+import (
+	"bytes"
+	"sort"
+)
+
+// Compare returns an integer comparing two CcIds: -1 if a < b, 0 if a == b, +1 if a > b.
+// It's compatible with slices.SortFunc and slices.BinarySearchFunc.
+// CcIds of the same size and timestamp precision are compared by raw bytes,
+// it's the same order as order of base62, base32 and base16 strings.
+// Other CcIds are compared by time first, then by raw bytes, a nil CcId is less than any other.
+func Compare(a, b CcId) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	case layoutOf(a) == layoutOf(b):
+		return bytes.Compare(a.Bytes(), b.Bytes())
+	}
+	if c := a.Time().Compare(b.Time()); c != 0 {
+		return c
+	}
+	return bytes.Compare(a.Bytes(), b.Bytes())
+}
+
+// Equal reports whether two CcIds have the same size, timestamp precision and bytes.
+// Internal fingerprint size is ignored, so byte-identical CcIds are equal.
+func Equal(a, b CcId) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return layoutOf(a) == layoutOf(b) && bytes.Equal(a.Bytes(), b.Bytes())
+}
+
+// Less reports whether CcId a sorts before b, see Compare.
+func Less(a, b CcId) bool {
+	return Compare(a, b) < 0
+}
+
+// CcIdSlice attaches the methods of sort.Interface to []CcId, sorting in increasing order by Compare.
+type CcIdSlice []CcId
+
+func (s CcIdSlice) Len() int           { return len(s) }
+func (s CcIdSlice) Less(i, j int) bool { return Less(s[i], s[j]) }
+func (s CcIdSlice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// Sort is a convenience method: s.Sort() calls sort.Sort(s).
+func (s CcIdSlice) Sort() { sort.Sort(s) }
@@ -0,0 +1,113 @@
+package pkg
+
+import (
+	"slices"
+	"testing"
+)
+
+func newCompareCcId(size byte, timestamp uint32, fingerprint []byte, fill byte) CcId {
+	ctor, _ := ccIdCtorBySize(size)
+	payload := make([]byte, size)
+	for i := range payload {
+		payload[i] = fill
+	}
+	id, _ := ctor(timestamp, fingerprint, payload)
+	return id
+}
+
+func newCompareCcIdMs(size byte, timestamp uint64, fill byte) CcId {
+	ctor, _ := ccIdMsCtorBySize(size)
+	payload := make([]byte, size)
+	for i := range payload {
+		payload[i] = fill
+	}
+	id, _ := ctor(timestamp, nil, payload)
+	return id
+}
+
+func TestCompare(t *testing.T) {
+	cases := map[string]struct {
+		a, b CcId
+		want int
+	}{
+		"nil nil":                 {nil, nil, 0},
+		"nil id":                  {nil, NilCcId64, -1},
+		"id nil":                  {NilCcId64, nil, 1},
+		"same":                    {newCompareCcId(ByteSliceSize96, 10, nil, 1), newCompareCcId(ByteSliceSize96, 10, nil, 1), 0},
+		"same bytes fingerprint":  {newCompareCcId(ByteSliceSize96, 10, []byte{1, 1}, 1), newCompareCcId(ByteSliceSize96, 10, nil, 1), 0},
+		"timestamp less":          {newCompareCcId(ByteSliceSize128, 9, nil, 0xff), newCompareCcId(ByteSliceSize128, 10, nil, 0), -1},
+		"payload greater":         {newCompareCcId(ByteSliceSize64, 10, nil, 2), newCompareCcId(ByteSliceSize64, 10, nil, 1), 1},
+		"sizes time less":         {newCompareCcId(ByteSliceSize160, 9, nil, 0xff), newCompareCcId(ByteSliceSize64, 10, nil, 0), -1},
+		"sizes time greater":      {newCompareCcId(ByteSliceSize64, 11, nil, 0), newCompareCcId(ByteSliceSize160, 10, nil, 0xff), 1},
+		"sizes same time bytes":   {newCompareCcId(ByteSliceSize96, 10, nil, 2), newCompareCcId(ByteSliceSize64, 10, nil, 1), 1},
+		"sizes same prefix":       {newCompareCcId(ByteSliceSize64, 10, nil, 1), newCompareCcId(ByteSliceSize96, 10, nil, 1), -1},
+		"ms same":                 {newCompareCcIdMs(ByteSliceSize96, 10001, 1), newCompareCcIdMs(ByteSliceSize96, 10001, 1), 0},
+		"ms time less":            {newCompareCcIdMs(ByteSliceSize128, 10001, 0xff), newCompareCcIdMs(ByteSliceSize128, 10002, 0), -1},
+		"ms seconds time less":    {newCompareCcIdMs(ByteSliceSize96, 9999, 0xff), newCompareCcId(ByteSliceSize96, 10, nil, 0), -1},
+		"ms seconds time greater": {newCompareCcIdMs(ByteSliceSize96, 10001, 0), newCompareCcId(ByteSliceSize96, 10, nil, 0xff), 1},
+	}
+	for _, key := range SortKeys(cases) {
+		tc := cases[key]
+		t.Run(key, func(t *testing.T) {
+			if got := Compare(tc.a, tc.b); got != tc.want {
+				t.Errorf("Compare(%v, %v) = %d, want %d", tc.a, tc.b, got, tc.want)
+			}
+			if got := Compare(tc.b, tc.a); got != -tc.want {
+				t.Errorf("Compare(%v, %v) = %d, want %d", tc.b, tc.a, got, -tc.want)
+			}
+			if got := Less(tc.a, tc.b); got != (tc.want < 0) {
+				t.Errorf("Less(%v, %v) = %t, want %t", tc.a, tc.b, got, tc.want < 0)
+			}
+			if got := Equal(tc.a, tc.b); got != (tc.want == 0) {
+				t.Errorf("Equal(%v, %v) = %t, want %t", tc.a, tc.b, got, tc.want == 0)
+			}
+		})
+	}
+}
+
+func TestCompare_StringOrder(t *testing.T) {
+	keys := SortKeys(TestCaseCcId160Map)
+	for _, ka := range keys {
+		for _, kb := range keys {
+			a, b := TestCaseCcId160Map[ka], TestCaseCcId160Map[kb]
+			idA, _ := NewCcId160WithFingerprint(a.timestamp, a.Fingerprint, a.payload)
+			idB, _ := NewCcId160WithFingerprint(b.timestamp, b.Fingerprint, b.payload)
+			c := Compare(idA, idB)
+			strCases := [][2]string{{a.Base62, b.Base62}, {a.Base32, b.Base32}, {a.Base16, b.Base16}}
+			for _, s := range strCases {
+				sc := 0
+				if s[0] < s[1] {
+					sc = -1
+				} else if s[0] > s[1] {
+					sc = 1
+				}
+				if sc != c {
+					t.Errorf("Compare(%s, %s) = %d, string order %d (%s, %s)", ka, kb, c, sc, s[0], s[1])
+				}
+			}
+		}
+	}
+}
+
+func TestCcIdSlice_Sort(t *testing.T) {
+	want := []CcId{
+		nil,
+		newCompareCcId(ByteSliceSize96, 9, nil, 0xff),
+		newCompareCcId(ByteSliceSize64, 10, nil, 1),
+		newCompareCcId(ByteSliceSize64, 10, nil, 2),
+		newCompareCcId(ByteSliceSize160, 10, nil, 2),
+		newCompareCcId(ByteSliceSize64, 11, nil, 0),
+	}
+	s := CcIdSlice{want[4], want[2], want[5], want[0], want[3], want[1]}
+	s.Sort()
+	f := []CcId{want[3], want[5], want[1], want[2], want[0], want[4]}
+	slices.SortFunc(f, Compare)
+	for i := range want {
+		if !Equal(s[i], want[i]) {
+			t.Errorf("CcIdSlice.Sort()[%d] = %v, want %v", i, s[i], want[i])
+		}
+		if !Equal(f[i], want[i]) {
+			t.Errorf("slices.SortFunc()[%d] = %v, want %v", i, f[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package pkg
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// TimeWithEpoch returns time of the CcId with timestamp counted from 'epoch', e.g. generated WithEpoch option.
+// CcIds don't keep the epoch, Time() always counts timestamp from DefaultEpoch.
+// Zero 'epoch' means DefaultEpoch, sub-second part of 'epoch' is truncated.
+func TimeWithEpoch(id CcId, epoch time.Time) time.Time {
+	if v, ok := id.(interface{ TimestampMs() uint64 }); ok {
+		return ToStandardizedTimeMsWithEpoch(v.TimestampMs(), epoch)
+	}
+	return ToStandardizedTimeWithEpoch(id.Timestamp(), epoch)
+}
+
+// Rebase converts CcId with timestamp counted from 'from' epoch to timestamp counted from 'to' epoch
+// keeping the same time, fingerprint and payload.
+// It returns TimestampOutOfRangeError if the time can't be represented on 'to' epoch.
+// Zero epoch means DefaultEpoch, sub-second part of epochs is truncated.
+func Rebase(id CcId, from time.Time, to time.Time) (CcId, error) {
+	if id == nil {
+		return nil, InvalidLengthError(0)
+	}
+	layout := layoutOf(id)
+	t := TimeWithEpoch(id, from)
+	b := append([]byte(nil), id.Bytes()...)
+	if layout.millisecond {
+		ts, err := AdjustTimestampMs(t, to, TimestampRangeError)
+		if err != nil {
+			return nil, err
+		}
+		PutUint48BigEndian(b[:TimestampMsSize], ts)
+	} else {
+		ts, err := AdjustTimestamp(t, to, TimestampRangeError)
+		if err != nil {
+			return nil, err
+		}
+		binary.BigEndian.PutUint32(b[:TimestampSize], ts)
+	}
+	return layout.fromBytes(b, byte(len(id.Fingerprint())))
+}
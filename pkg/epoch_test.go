@@ -0,0 +1,73 @@
+package pkg
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTimeWithEpoch(t *testing.T) {
+	tc := TestCaseCcId96Map["some id fingerprint"]
+	id, _ := NewCcId96WithFingerprint(tc.timestamp, tc.Fingerprint, tc.payload)
+	tcMs := TestCaseCcId128MsMap["fingerprint"]
+	idMs, _ := NewCcId128MsWithFingerprint(tcMs.timestamp, tcMs.Fingerprint, tcMs.payload)
+	cases := map[string]struct {
+		epoch  time.Time
+		want   time.Time
+		wantMs time.Time
+	}{
+		"zero":    {time.Time{}, tc.time, idMs.Time()},
+		"default": {DefaultEpoch, tc.time, idMs.Time()},
+		"unix":    {UnixEpoch, time.Unix(int64(tc.timestamp), 0).UTC(), time.UnixMilli(int64(tcMs.timestamp)).UTC()},
+		"2020": {time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Unix(1577836800+int64(tc.timestamp), 0).UTC(),
+			time.UnixMilli(1577836800000 + int64(tcMs.timestamp)).UTC()},
+	}
+	for _, key := range SortKeys(cases) {
+		c := cases[key]
+		t.Run(key, func(t *testing.T) {
+			if got := TimeWithEpoch(id, c.epoch); !got.Equal(c.want) {
+				t.Errorf("TimeWithEpoch(%s) = %s, want %s", c.epoch, got, c.want)
+			}
+			if got := TimeWithEpoch(idMs, c.epoch); !got.Equal(c.wantMs) {
+				t.Errorf("TimeWithEpoch(%s) = %s, want %s", c.epoch, got, c.wantMs)
+			}
+			if ts := ToAdjustedTimestampWithEpoch(c.want, c.epoch); ts != tc.timestamp {
+				t.Errorf("ToAdjustedTimestampWithEpoch(%s, %s) = %d, want %d", c.want, c.epoch, ts, tc.timestamp)
+			}
+		})
+	}
+}
+
+func TestRebase(t *testing.T) {
+	tc := TestCaseCcId160Map["some id fingerprint"]
+	tcMs := TestCaseCcId128MsMap["fingerprint"]
+	id, _ := NewCcId160WithFingerprint(tc.timestamp, tc.Fingerprint, tc.payload)
+	idMs, _ := NewCcId128MsWithFingerprint(tcMs.timestamp, tcMs.Fingerprint, tcMs.payload)
+	for _, v := range []CcId{id, idMs} {
+		got, err := Rebase(v, time.Time{}, UnixEpoch)
+		if err != nil {
+			t.Errorf("Rebase(%#v) error = %v", v, err)
+			continue
+		}
+		if !TimeWithEpoch(got, UnixEpoch).Equal(v.Time()) {
+			t.Errorf("Rebase(%#v) = %#v, want the same time on UnixEpoch", v, got)
+		}
+		if !SliceEqual(got.Fingerprint(), v.Fingerprint()) || !SliceEqual(got.Payload(), v.Payload()) {
+			t.Errorf("Rebase(%#v) = %#v, want the same fingerprint and payload", v, got)
+		}
+		back, err := Rebase(got, UnixEpoch, time.Time{})
+		if err != nil || back != v {
+			t.Errorf("Rebase(%#v) = %#v, %v, want %#v", got, back, err, v)
+		}
+	}
+	var rangeErr TimestampOutOfRangeError
+	if _, err := Rebase(id, time.Time{}, time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)); !errors.As(err, &rangeErr) {
+		t.Errorf("Rebase(2100) error = %v, want TimestampOutOfRangeError", err)
+	}
+	if _, err := Rebase(idMs, time.Time{}, time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)); !errors.As(err, &rangeErr) {
+		t.Errorf("Rebase(2100) error = %v, want TimestampOutOfRangeError", err)
+	}
+	if _, err := Rebase(nil, time.Time{}, UnixEpoch); !errors.Is(err, InvalidLengthError(0)) {
+		t.Errorf("Rebase(nil) error = %v, want InvalidLengthError", err)
+	}
+}
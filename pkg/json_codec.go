@@ -0,0 +1,238 @@
+package pkg
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// jsonBases keeps bases of MarshalJSON, index 0 is the package default, others are per-type overrides,
+// see jsonBaseIdx. Zero value means not set.
+var jsonBases [8]atomic.Uint32
+
+func jsonBaseIdx(l ccIdLayout) int {
+	idx := int(l.size-4) / 4
+	if l.millisecond {
+		idx += 3
+	}
+	return idx
+}
+
+func jsonBase(l ccIdLayout) byte {
+	if base := jsonBases[jsonBaseIdx(l)].Load(); base != 0 {
+		return byte(base)
+	}
+	if base := jsonBases[0].Load(); base != 0 {
+		return byte(base)
+	}
+	return BASE62
+}
+
+// SetJSONBase sets the base of CcIds encoded by MarshalJSON, it's BASE62 by default.
+// 'base' must be BASE62, BASE32 or BASE16, 0 resets it to BASE62.
+// It's meant to be called on start up, see SetJSONBaseFor for a single type and JSONField for a single field.
+func SetJSONBase(base byte) error {
+	if base != 0 && !isValidBase(base) {
+		return InvalidBaseError(base)
+	}
+	jsonBases[0].Store(uint32(base))
+	return nil
+}
+
+// SetJSONBaseFor sets the base of MarshalJSON for the type of 'id', e.g. NilCcId128, it overrides SetJSONBase.
+// 'base' must be BASE62, BASE32 or BASE16, 0 resets the type to the package default.
+func SetJSONBaseFor(id CcId, base byte) error {
+	if id == nil {
+		return InvalidLengthError(0)
+	}
+	if base != 0 && !isValidBase(base) {
+		return InvalidBaseError(base)
+	}
+	l := layoutOf(id)
+	if _, err := l.nilCcId(); err != nil {
+		return err
+	}
+	jsonBases[jsonBaseIdx(l)].Store(uint32(base))
+	return nil
+}
+
+// JSONBase returns the base of CcIds of the type of 'id' encoded by MarshalJSON.
+func JSONBase(id CcId) byte {
+	return jsonBase(layoutOf(id))
+}
+
+// JSONBaseOption selects the base of JSONField, it's one of JSONBase62, JSONBase32 and JSONBase16.
+type JSONBaseOption interface {
+	jsonBase() byte
+}
+
+// JSONBase62 renders JSONField in base62.
+type JSONBase62 struct{}
+
+// JSONBase32 renders JSONField in Crockford base32.
+type JSONBase32 struct{}
+
+// JSONBase16 renders JSONField in base16.
+type JSONBase16 struct{}
+
+func (JSONBase62) jsonBase() byte { return BASE62 }
+func (JSONBase32) jsonBase() byte { return BASE32 }
+func (JSONBase16) jsonBase() byte { return BASE16 }
+
+// JSONField is a struct field with CcId 'T' rendered in base 'B', it's the typed form of `ccid:"base32"` option:
+//
+//	type Order struct {
+//		Id pkg.JSONField[pkg.CcId128, pkg.JSONBase32] `json:"id"`
+//	}
+//
+// Nil CcIds are encoded as null. Decoding accepts null and any base like UnmarshalJSON of 'T'.
+type JSONField[T CcId64 | CcId96 | CcId128 | CcId160 | CcId96Ms | CcId128Ms | CcId160Ms, B JSONBaseOption] struct {
+	Id T
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (f JSONField[T, B]) MarshalJSON() ([]byte, error) {
+	id := any(f.Id).(CcId)
+	if isNilCcId(id) {
+		return []byte("null"), nil
+	}
+	var b B
+	return marshalJSON(id.Bytes(), b.jsonBase())
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (f *JSONField[T, B]) UnmarshalJSON(data []byte) error {
+	return any(&f.Id).(json.Unmarshaler).UnmarshalJSON(data)
+}
+
+// JSONFieldError is returned when a CcId can't be decoded from JSON.
+// DecodeJSON sets Path of the field, e.g. "orders[2].id", json.Unmarshal leaves it empty.
+type JSONFieldError struct {
+	Path string
+	Err  error
+	// raw JSON value, it's used to find the path
+	value []byte
+}
+
+func (e JSONFieldError) Error() string {
+	if e.Path == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%s (json field %q)", e.Err, e.Path)
+}
+
+func (e JSONFieldError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeJSON is json.Unmarshal reporting the path of the failed CcId field in JSONFieldError,
+// e.g. next to the position of InvalidCharacterError.
+func DecodeJSON(data []byte, v any) error {
+	err := json.Unmarshal(data, v)
+	var fieldErr JSONFieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Path != "" {
+		return err
+	}
+	offsets, paths := jsonValuePaths(data, fieldErr.value)
+	switch len(offsets) {
+	case 0:
+		return err
+	case 1:
+		fieldErr.Path = paths[0]
+		return fieldErr
+	}
+	// the failed value is in several fields, they are replaced by unique markers to find the failed one,
+	// then 'v' is decoded again to restore the state of the failed decoding
+	idx := 0
+	markers, marked := markJSONValues(data, offsets, len(fieldErr.value))
+	var markErr JSONFieldError
+	if errors.As(json.Unmarshal(marked, v), &markErr) {
+		for i, m := range markers {
+			if bytes.Equal(m, markErr.value) {
+				idx = i
+			}
+		}
+	}
+	_ = json.Unmarshal(data, v)
+	fieldErr.Path = paths[idx]
+	return fieldErr
+}
+
+// markJSONValues returns a copy of 'data' with values of 'size' bytes at 'offsets' replaced by unique markers.
+func markJSONValues(data []byte, offsets []int, size int) ([][]byte, []byte) {
+	markers := make([][]byte, len(offsets))
+	res := make([]byte, 0, len(data)+len(offsets)*16)
+	prev := 0
+	for i, offset := range offsets {
+		markers[i] = []byte(fmt.Sprintf(`"\u0000%d"`, i))
+		res = append(res, data[prev:offset]...)
+		res = append(res, markers[i]...)
+		prev = offset + size
+	}
+	return markers, append(res, data[prev:]...)
+}
+
+// jsonValuePaths returns offsets and paths of JSON values of 'data' equal to 'value', e.g. "orders[2].id".
+func jsonValuePaths(data []byte, value []byte) ([]int, []string) {
+	type frame struct {
+		array   bool
+		key     string
+		index   int
+		wantKey bool
+	}
+	var stack []frame
+	var offsets []int
+	var paths []string
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return offsets, paths
+		}
+		if tok == json.Delim('}') || tok == json.Delim(']') {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		top := len(stack) - 1
+		if top >= 0 && stack[top].wantKey {
+			stack[top].key, stack[top].wantKey = tok.(string), false
+			continue
+		}
+		if top >= 0 {
+			if stack[top].array {
+				stack[top].index++
+			} else {
+				stack[top].wantKey = true
+			}
+		}
+		// value token ends at the input offset, an object or array value starts with its delimiter
+		offset := int(dec.InputOffset()) - len(value)
+		if _, ok := tok.(json.Delim); ok {
+			offset = int(dec.InputOffset()) - 1
+		}
+		if offset >= 0 && bytes.HasPrefix(data[offset:], value) {
+			var path strings.Builder
+			for _, f := range stack {
+				if f.array {
+					fmt.Fprintf(&path, "[%d]", f.index)
+					continue
+				}
+				if path.Len() > 0 {
+					path.WriteByte('.')
+				}
+				path.WriteString(f.key)
+			}
+			offsets = append(offsets, offset)
+			paths = append(paths, path.String())
+		}
+		switch tok {
+		case json.Delim('{'):
+			stack = append(stack, frame{wantKey: true})
+		case json.Delim('['):
+			stack = append(stack, frame{array: true, index: -1})
+		}
+	}
+}
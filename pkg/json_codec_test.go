@@ -0,0 +1,184 @@
+package pkg
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestSetJSONBase(t *testing.T) {
+	t.Cleanup(func() {
+		_ = SetJSONBase(0)
+		_ = SetJSONBaseFor(NilCcId128, 0)
+	})
+	tc96 := TestCaseCcId96Map["max fingerprint"]
+	tc128 := TestCaseCcId128Map["max fingerprint"]
+	tcMs := TestCaseCcId128MsMap["fingerprint"]
+	id96, _ := NewCcId96Value(tc96.timestamp, tc96.Fingerprint, tc96.payload)
+	id128, _ := NewCcId128Value(tc128.timestamp, tc128.Fingerprint, tc128.payload)
+	idMs, _ := NewCcId128MsValue(tcMs.timestamp, tcMs.Fingerprint, tcMs.payload)
+	if err := SetJSONBase(BASE32); err != nil {
+		t.Fatalf("SetJSONBase() error = %v", err)
+	}
+	if err := SetJSONBaseFor(NilCcId128, BASE16); err != nil {
+		t.Fatalf("SetJSONBaseFor() error = %v", err)
+	}
+	cases := map[string]struct {
+		v    any
+		want string
+	}{
+		"default":       {id96, tc96.Base32},
+		"type":          {id128, tc128.Base16},
+		"millisecond":   {idMs, tcMs.Base32},
+		"json ccid":     {JSONCcId{Id: id128}, tc128.Base16},
+		"json ccid set": {JSONCcId{Id: id128, Base: BASE62}, tc128.Base62},
+	}
+	for _, key := range SortKeys(cases) {
+		c := cases[key]
+		t.Run(key, func(t *testing.T) {
+			got, err := json.Marshal(c.v)
+			if err != nil || string(got) != `"`+c.want+`"` {
+				t.Errorf("json.Marshal() = %s, %v, want %q", got, err, c.want)
+			}
+		})
+	}
+	if got := JSONBase(NilCcId128Ms); got != BASE32 {
+		t.Errorf("JSONBase(CcId128Ms) = %d, want %d", got, BASE32)
+	}
+	_ = SetJSONBaseFor(NilCcId128, 0)
+	_ = SetJSONBase(0)
+	if got := JSONBase(id128); got != BASE62 {
+		t.Errorf("JSONBase() after reset = %d, want %d", got, BASE62)
+	}
+}
+
+func TestSetJSONBase_Error(t *testing.T) {
+	var baseErr InvalidBaseError
+	if err := SetJSONBase(10); !errors.As(err, &baseErr) {
+		t.Errorf("SetJSONBase(10) error = %v, want InvalidBaseError", err)
+	}
+	if err := SetJSONBaseFor(NilCcId64, 10); !errors.As(err, &baseErr) {
+		t.Errorf("SetJSONBaseFor(10) error = %v, want InvalidBaseError", err)
+	}
+	var lengthErr InvalidLengthError
+	if err := SetJSONBaseFor(nil, BASE32); !errors.As(err, &lengthErr) {
+		t.Errorf("SetJSONBaseFor(nil) error = %v, want InvalidLengthError", err)
+	}
+	if got := JSONBase(NilCcId64); got != BASE62 {
+		t.Errorf("JSONBase() = %d, want %d", got, BASE62)
+	}
+}
+
+func TestJSONField(t *testing.T) {
+	type dto struct {
+		A JSONField[CcId128, JSONBase32]   `json:"a"`
+		B JSONField[CcId96Ms, JSONBase16]  `json:"b"`
+		C JSONField[CcId64, JSONBase62]    `json:"c"`
+		D JSONField[CcId160Ms, JSONBase32] `json:"d"`
+	}
+	tc := TestCaseCcId128Map["max fingerprint"]
+	tcMs := TestCaseCcId96MsMap["fingerprint"]
+	tc64 := TestCaseCcId64Map["max id"]
+	id, _ := NewCcId128Value(tc.timestamp, tc.Fingerprint, tc.payload)
+	idMs, _ := NewCcId96MsValue(tcMs.timestamp, tcMs.Fingerprint, tcMs.payload)
+	id64, _ := NewCcId64Value(tc64.timestamp, tc64.Fingerprint, tc64.payload)
+	in := dto{}
+	in.A.Id, in.B.Id, in.C.Id = id, idMs, id64
+	b, err := json.Marshal(in)
+	want := `{"a":"` + tc.Base32 + `","b":"` + tcMs.Base16 + `","c":"` + tc64.Base62 + `","d":null}`
+	if err != nil || string(b) != want {
+		t.Fatalf("json.Marshal() =\n%s, %v, want\n%s", b, err, want)
+	}
+	var out dto
+	out.A.Id, _ = NewCcId128Value(0, make([]byte, len(tc.Fingerprint)), make([]byte, ByteSliceSize128))
+	out.B.Id, _ = NewCcId96MsValue(0, make([]byte, len(tcMs.Fingerprint)), make([]byte, ByteSliceSize96))
+	out.C.Id, _ = NewCcId64Value(0, make([]byte, len(tc64.Fingerprint)), make([]byte, ByteSliceSize64))
+	err = json.Unmarshal(b, &out)
+	if err != nil || out != in {
+		t.Errorf("json.Unmarshal() =\n%#v, %v, want\n%#v", out, err, in)
+	}
+	if fmt.Sprintf("%#v", out.A.Id) != tc.GoString {
+		t.Errorf("json.Unmarshal() =\n%#v, want\n%s", out.A.Id, tc.GoString)
+	}
+}
+
+func TestDecodeJSON(t *testing.T) {
+	type order struct {
+		Id JSONField[CcId64, JSONBase62] `json:"id"`
+	}
+	type dto struct {
+		Name   string  `json:"name"`
+		Owner  CcId64  `json:"owner"`
+		Orders []order `json:"orders"`
+	}
+	tc := TestCaseCcId64Map["max id"]
+	cases := map[string]struct {
+		src  string
+		path string
+		err  error
+	}{
+		"root":       {`"00000*00000"`, "", InvalidCharacterError{'*', 5}},
+		"field":      {`{"name":"00000*00000","owner":"00000*00000"}`, "owner", InvalidCharacterError{'*', 5}},
+		"nested":     {`{"orders":[{"id":"` + tc.Base62 + `"},{"id": "0000"}]}`, "orders[1].id", InvalidLengthError(4)},
+		"character":  {`{"owner":"` + tc.Base62 + `", "orders":[{"id":"` + tc.Base62[:3] + `*` + tc.Base62[4:] + `"}]}`, "orders[0].id", InvalidCharacterError{'*', 3}},
+		"not string": {`{"owner":12}`, "owner", nil},
+		"repeated":   {`{"name":"0000","orders":[{"id":"0000"}],"owner":"0000"}`, "orders[0].id", InvalidLengthError(4)},
+	}
+	for _, key := range SortKeys(cases) {
+		c := cases[key]
+		t.Run(key, func(t *testing.T) {
+			var v dto
+			var target any = &v
+			if c.path == "" {
+				target = &v.Owner
+			}
+			err := DecodeJSON([]byte(c.src), target)
+			var fieldErr JSONFieldError
+			if !errors.As(err, &fieldErr) || fieldErr.Path != c.path || (c.err != nil && !errors.Is(err, c.err)) {
+				t.Errorf("DecodeJSON(%s) error = %v, want %v at %q", c.src, err, c.err, c.path)
+			}
+		})
+	}
+	var v dto
+	err := DecodeJSON([]byte(`{"name":"n","owner":"`+tc.Base62+`"}`), &v)
+	if err != nil || !SliceEqual(v.Owner.Bytes(), tc.Bytes) || v.Name != "n" {
+		t.Errorf("DecodeJSON() = %#v, %v, want owner %x", v, err, tc.Bytes)
+	}
+	var fieldErr JSONFieldError
+	err = json.Unmarshal([]byte(`{"owner":"0000"}`), &v)
+	if !errors.As(err, &fieldErr) || fieldErr.Path != "" || err.Error() != InvalidLengthError(4).Error() {
+		t.Errorf("json.Unmarshal() error = %v, want InvalidLengthError without path", err)
+	}
+}
+
+func TestJSONValuePaths(t *testing.T) {
+	data := `{"a":1,"b":{"c":[10,{"d":"x"},[true,null]]},"e":"x", "f" : [ "x" ]}`
+	cases := map[string]struct {
+		value string
+		want  []string
+	}{
+		"number":   {"1", []string{"a"}},
+		"object":   {`{"c":[10,{"d":"x"},[true,null]]}`, []string{"b"}},
+		"array":    {`[10,{"d":"x"},[true,null]]`, []string{"b.c"}},
+		"element":  {"10", []string{"b.c[0]"}},
+		"nested":   {"null", []string{"b.c[2][1]"}},
+		"repeated": {`"x"`, []string{"b.c[1].d", "e", "f[0]"}},
+		"root":     {data, []string{""}},
+		"missing":  {`"y"`, nil},
+	}
+	for _, key := range SortKeys(cases) {
+		c := cases[key]
+		t.Run(key, func(t *testing.T) {
+			offsets, paths := jsonValuePaths([]byte(data), []byte(c.value))
+			if fmt.Sprint(paths) != fmt.Sprint(c.want) {
+				t.Errorf("jsonValuePaths(%s) = %q, want %q", c.value, paths, c.want)
+			}
+			for _, offset := range offsets {
+				if data[offset:offset+len(c.value)] != c.value {
+					t.Errorf("jsonValuePaths(%s) offset %d = %s", c.value, offset, data[offset:offset+len(c.value)])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package pkg
+
+// Binary layout is 1 byte header followed by raw CcId bytes.
+// Header bits 0-2 keep CcId size in 4 bytes words (2 - 5), bits 3-5 keep fingerprint size (0 - 5).
+// Bit 6 is set for millisecond CcIds, bit 7 is reserved and must be 0.
+const (
+	binaryHeaderSize         = 1
+	binaryHeaderSizeMask     = 0x07
+	binaryHeaderFpShift      = 3
+	binaryHeaderFpMask       = 0x07
+	binaryHeaderMsFlag       = 0x40
+	binaryHeaderReservedMask = 0x80
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler interface.
+// Encoded value keeps fingerprint size, so Fingerprint() and Payload() are the same after decoding.
+func (id CcId64) MarshalBinary() ([]byte, error) {
+	return marshalBinary(id.data[:], id.fingerprintSize, false), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface.
+func (id *CcId64) UnmarshalBinary(data []byte) error {
+	v, err := unmarshalBinary(data, ccIdLayout{size: ByteSliceSize64})
+	if err != nil {
+		return err
+	}
+	*id = v.(CcId64)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder interface.
+func (id CcId64) GobEncode() ([]byte, error) {
+	return id.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder interface.
+func (id *CcId64) GobDecode(data []byte) error {
+	return id.UnmarshalBinary(data)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler interface.
+// Encoded value keeps fingerprint size, so Fingerprint() and Payload() are the same after decoding.
+func (id CcId96) MarshalBinary() ([]byte, error) {
+	return marshalBinary(id.data[:], id.fingerprintSize, false), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface.
+func (id *CcId96) UnmarshalBinary(data []byte) error {
+	v, err := unmarshalBinary(data, ccIdLayout{size: ByteSliceSize96})
+	if err != nil {
+		return err
+	}
+	*id = v.(CcId96)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder interface.
+func (id CcId96) GobEncode() ([]byte, error) {
+	return id.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder interface.
+func (id *CcId96) GobDecode(data []byte) error {
+	return id.UnmarshalBinary(data)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler interface.
+// Encoded value keeps fingerprint size, so Fingerprint() and Payload() are the same after decoding.
+func (id CcId128) MarshalBinary() ([]byte, error) {
+	return marshalBinary(id.data[:], id.fingerprintSize, false), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface.
+func (id *CcId128) UnmarshalBinary(data []byte) error {
+	v, err := unmarshalBinary(data, ccIdLayout{size: ByteSliceSize128})
+	if err != nil {
+		return err
+	}
+	*id = v.(CcId128)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder interface.
+func (id CcId128) GobEncode() ([]byte, error) {
+	return id.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder interface.
+func (id *CcId128) GobDecode(data []byte) error {
+	return id.UnmarshalBinary(data)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler interface.
+// Encoded value keeps fingerprint size, so Fingerprint() and Payload() are the same after decoding.
+func (id CcId160) MarshalBinary() ([]byte, error) {
+	return marshalBinary(id.data[:], id.fingerprintSize, false), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface.
+func (id *CcId160) UnmarshalBinary(data []byte) error {
+	v, err := unmarshalBinary(data, ccIdLayout{size: ByteSliceSize160})
+	if err != nil {
+		return err
+	}
+	*id = v.(CcId160)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder interface.
+func (id CcId160) GobEncode() ([]byte, error) {
+	return id.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder interface.
+func (id *CcId160) GobDecode(data []byte) error {
+	return id.UnmarshalBinary(data)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler interface.
+// Encoded value keeps fingerprint size, so Fingerprint() and Payload() are the same after decoding.
+func (id CcId96Ms) MarshalBinary() ([]byte, error) {
+	return marshalBinary(id.data[:], id.fingerprintSize, true), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface.
+func (id *CcId96Ms) UnmarshalBinary(data []byte) error {
+	v, err := unmarshalBinary(data, ccIdLayout{size: ByteSliceSize96, millisecond: true})
+	if err != nil {
+		return err
+	}
+	*id = v.(CcId96Ms)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder interface.
+func (id CcId96Ms) GobEncode() ([]byte, error) {
+	return id.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder interface.
+func (id *CcId96Ms) GobDecode(data []byte) error {
+	return id.UnmarshalBinary(data)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler interface.
+// Encoded value keeps fingerprint size, so Fingerprint() and Payload() are the same after decoding.
+func (id CcId128Ms) MarshalBinary() ([]byte, error) {
+	return marshalBinary(id.data[:], id.fingerprintSize, true), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface.
+func (id *CcId128Ms) UnmarshalBinary(data []byte) error {
+	v, err := unmarshalBinary(data, ccIdLayout{size: ByteSliceSize128, millisecond: true})
+	if err != nil {
+		return err
+	}
+	*id = v.(CcId128Ms)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder interface.
+func (id CcId128Ms) GobEncode() ([]byte, error) {
+	return id.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder interface.
+func (id *CcId128Ms) GobDecode(data []byte) error {
+	return id.UnmarshalBinary(data)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler interface.
+// Encoded value keeps fingerprint size, so Fingerprint() and Payload() are the same after decoding.
+func (id CcId160Ms) MarshalBinary() ([]byte, error) {
+	return marshalBinary(id.data[:], id.fingerprintSize, true), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface.
+func (id *CcId160Ms) UnmarshalBinary(data []byte) error {
+	v, err := unmarshalBinary(data, ccIdLayout{size: ByteSliceSize160, millisecond: true})
+	if err != nil {
+		return err
+	}
+	*id = v.(CcId160Ms)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder interface.
+func (id CcId160Ms) GobEncode() ([]byte, error) {
+	return id.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder interface.
+func (id *CcId160Ms) GobDecode(data []byte) error {
+	return id.UnmarshalBinary(data)
+}
+
+func marshalBinary(b []byte, fingerprintSize byte, millisecond bool) []byte {
+	res := make([]byte, binaryHeaderSize+len(b))
+	res[0] = fingerprintSize<<binaryHeaderFpShift | byte(len(b))>>2
+	if millisecond {
+		res[0] |= binaryHeaderMsFlag
+	}
+	copy(res[binaryHeaderSize:], b)
+	return res
+}
+
+func unmarshalBinary(data []byte, layout ccIdLayout) (CcId, error) {
+	if len(data) != binaryHeaderSize+int(layout.size) {
+		return nil, InvalidLengthError(byte(len(data)))
+	}
+	header := data[0]
+	fingerprintSize := (header >> binaryHeaderFpShift) & binaryHeaderFpMask
+	if header&binaryHeaderReservedMask != 0 || (header&binaryHeaderSizeMask)<<2 != layout.size ||
+		(header&binaryHeaderMsFlag != 0) != layout.millisecond {
+		return nil, InvalidHeaderError(header)
+	}
+	return layout.fromBytes(data[binaryHeaderSize:], fingerprintSize)
+}
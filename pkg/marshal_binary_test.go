@@ -0,0 +1,118 @@
+package pkg
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestMarshalBinary(t *testing.T) {
+	groups := map[string]map[string]CcIdTestCases{
+		"ccid64":  TestCaseCcId64Map,
+		"ccid96":  TestCaseCcId96Map,
+		"ccid128": TestCaseCcId128Map,
+		"ccid160": TestCaseCcId160Map,
+	}
+	for _, name := range SortKeys(groups) {
+		m := groups[name]
+		for _, key := range SortKeys(m) {
+			tc := m[key]
+			ctor, _ := ccIdCtorBySize(byte(len(tc.Bytes)))
+			id, _ := ctor(tc.timestamp, tc.Fingerprint, tc.payload)
+			t.Run(name+"_"+key, func(t *testing.T) {
+				b, err := id.(encoding.BinaryMarshaler).MarshalBinary()
+				if err != nil {
+					t.Errorf("MarshalBinary() error = %v", err)
+					return
+				}
+				header := byte(len(id.Fingerprint()))<<3 | id.Size()>>2
+				if b[0] != header || !SliceEqual(b[1:], tc.Bytes) {
+					t.Errorf("MarshalBinary() =\n%x, want\n%02x%x", b, header, tc.Bytes)
+				}
+				got := newBinaryUnmarshaler(id.Size())
+				err = got.UnmarshalBinary(b)
+				if err != nil {
+					t.Errorf("UnmarshalBinary(%x) error = %v", b, err)
+					return
+				}
+				if v := fmt.Sprintf("%#v", got); v != tc.GoString {
+					t.Errorf("UnmarshalBinary(%x) =\n%s, want\n%s", b, v, tc.GoString)
+				}
+			})
+		}
+	}
+}
+
+func TestUnmarshalBinary_Error(t *testing.T) {
+	var id CcId96
+	var lengthErr InvalidLengthError
+	var headerErr InvalidHeaderError
+	var fpErr InvalidFingerprintSizeError
+	cases := map[string]struct {
+		data   []byte
+		target any
+	}{
+		"empty":             {[]byte{}, &lengthErr},
+		"short":             {make([]byte, ByteSliceSize96), &lengthErr},
+		"other size":        {append([]byte{ByteSliceSize64 >> 2}, make([]byte, ByteSliceSize96)...), &headerErr},
+		"reserved bits":     {append([]byte{0x80 | ByteSliceSize96>>2}, make([]byte, ByteSliceSize96)...), &headerErr},
+		"large fingerprint": {append([]byte{6<<3 | ByteSliceSize96>>2}, make([]byte, ByteSliceSize96)...), &fpErr},
+	}
+	for _, key := range SortKeys(cases) {
+		c := cases[key]
+		t.Run(key, func(t *testing.T) {
+			err := id.UnmarshalBinary(c.data)
+			if !errors.As(err, c.target) {
+				t.Errorf("UnmarshalBinary(%x) error = %v, want %T", c.data, err, c.target)
+			}
+		})
+	}
+}
+
+func TestGob(t *testing.T) {
+	type message struct {
+		A CcId64
+		B CcId96
+		C CcId128
+		D CcId160
+		E CcId
+	}
+	id64, _ := NewCcId64WithFingerprint(0x12345678, []byte{0x99}, []byte{1, 2, 3})
+	id96, _ := NewCcId96WithFingerprint(0x12345678, []byte{0x99, 0x88}, make([]byte, 6))
+	id128, _ := NewCcId128WithFingerprint(0x12345678, []byte{0x99, 0x88, 0x77}, make([]byte, 9))
+	id160, _ := NewCcId160WithFingerprint(0x12345678, []byte{1, 2, 3, 4, 5}, make([]byte, 11))
+	gob.Register(CcId96{})
+	in := message{id64.(CcId64), id96.(CcId96), id128.(CcId128), id160.(CcId160), id96}
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(in)
+	if err != nil {
+		t.Errorf("gob.Encode() error = %v", err)
+		return
+	}
+	var out message
+	err = gob.NewDecoder(&buf).Decode(&out)
+	if err != nil {
+		t.Errorf("gob.Decode() error = %v", err)
+		return
+	}
+	if out != in {
+		t.Errorf("gob.Decode() =\n%#v, want\n%#v", out, in)
+	}
+}
+
+func newBinaryUnmarshaler(size byte) encoding.BinaryUnmarshaler {
+	switch size {
+	case ByteSliceSize64:
+		return &CcId64{}
+	case ByteSliceSize96:
+		return &CcId96{}
+	case ByteSliceSize128:
+		return &CcId128{}
+	case ByteSliceSize160:
+		return &CcId160{}
+	}
+	return nil
+}
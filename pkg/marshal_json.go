@@ -0,0 +1,291 @@
+package pkg
+
+import (
+	"encoding/json"
+)
+
+// MarshalJSON implements json.Marshaler interface.
+// CcId64 with zero bytes, e.g. NilCcId64, is encoded as null, other values as string in JSONBase of the type, base62 by default.
+func (id CcId64) MarshalJSON() ([]byte, error) {
+	if id.data == NilCcId64.data {
+		return []byte("null"), nil
+	}
+	return marshalJSON(id.data[:], jsonBase(ccIdLayout{size: ByteSliceSize64}))
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// It accepts null and base62, base32 or base16 string, fingerprint size of the receiver is preserved.
+func (id *CcId64) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalJSON(data, ccIdLayout{size: ByteSliceSize64}, id.fingerprintSize)
+	if err != nil {
+		return err
+	}
+	*id = v.(CcId64)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// CcId96 with zero bytes, e.g. NilCcId96, is encoded as null, other values as string in JSONBase of the type, base62 by default.
+func (id CcId96) MarshalJSON() ([]byte, error) {
+	if id.data == NilCcId96.data {
+		return []byte("null"), nil
+	}
+	return marshalJSON(id.data[:], jsonBase(ccIdLayout{size: ByteSliceSize96}))
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// It accepts null and base62, base32 or base16 string, fingerprint size of the receiver is preserved.
+func (id *CcId96) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalJSON(data, ccIdLayout{size: ByteSliceSize96}, id.fingerprintSize)
+	if err != nil {
+		return err
+	}
+	*id = v.(CcId96)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// CcId128 with zero bytes, e.g. NilCcId128, is encoded as null, other values as string in JSONBase of the type, base62 by default.
+func (id CcId128) MarshalJSON() ([]byte, error) {
+	if id.data == NilCcId128.data {
+		return []byte("null"), nil
+	}
+	return marshalJSON(id.data[:], jsonBase(ccIdLayout{size: ByteSliceSize128}))
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// It accepts null and base62, base32 or base16 string, fingerprint size of the receiver is preserved.
+func (id *CcId128) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalJSON(data, ccIdLayout{size: ByteSliceSize128}, id.fingerprintSize)
+	if err != nil {
+		return err
+	}
+	*id = v.(CcId128)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// CcId160 with zero bytes, e.g. NilCcId160, is encoded as null, other values as string in JSONBase of the type, base62 by default.
+func (id CcId160) MarshalJSON() ([]byte, error) {
+	if id.data == NilCcId160.data {
+		return []byte("null"), nil
+	}
+	return marshalJSON(id.data[:], jsonBase(ccIdLayout{size: ByteSliceSize160}))
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// It accepts null and base62, base32 or base16 string, fingerprint size of the receiver is preserved.
+func (id *CcId160) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalJSON(data, ccIdLayout{size: ByteSliceSize160}, id.fingerprintSize)
+	if err != nil {
+		return err
+	}
+	*id = v.(CcId160)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// CcId96Ms with zero bytes, e.g. NilCcId96Ms, is encoded as null, other values as string in JSONBase of the type, base62 by default.
+func (id CcId96Ms) MarshalJSON() ([]byte, error) {
+	if id.data == NilCcId96Ms.data {
+		return []byte("null"), nil
+	}
+	return marshalJSON(id.data[:], jsonBase(ccIdLayout{size: ByteSliceSize96, millisecond: true}))
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// It accepts null and base62, base32 or base16 string, fingerprint size of the receiver is preserved.
+func (id *CcId96Ms) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalJSON(data, ccIdLayout{size: ByteSliceSize96, millisecond: true}, id.fingerprintSize)
+	if err != nil {
+		return err
+	}
+	*id = v.(CcId96Ms)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// CcId128Ms with zero bytes, e.g. NilCcId128Ms, is encoded as null, other values as string in JSONBase of the type, base62 by default.
+func (id CcId128Ms) MarshalJSON() ([]byte, error) {
+	if id.data == NilCcId128Ms.data {
+		return []byte("null"), nil
+	}
+	return marshalJSON(id.data[:], jsonBase(ccIdLayout{size: ByteSliceSize128, millisecond: true}))
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// It accepts null and base62, base32 or base16 string, fingerprint size of the receiver is preserved.
+func (id *CcId128Ms) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalJSON(data, ccIdLayout{size: ByteSliceSize128, millisecond: true}, id.fingerprintSize)
+	if err != nil {
+		return err
+	}
+	*id = v.(CcId128Ms)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// CcId160Ms with zero bytes, e.g. NilCcId160Ms, is encoded as null, other values as string in JSONBase of the type, base62 by default.
+func (id CcId160Ms) MarshalJSON() ([]byte, error) {
+	if id.data == NilCcId160Ms.data {
+		return []byte("null"), nil
+	}
+	return marshalJSON(id.data[:], jsonBase(ccIdLayout{size: ByteSliceSize160, millisecond: true}))
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// It accepts null and base62, base32 or base16 string, fingerprint size of the receiver is preserved.
+func (id *CcId160Ms) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalJSON(data, ccIdLayout{size: ByteSliceSize160, millisecond: true}, id.fingerprintSize)
+	if err != nil {
+		return err
+	}
+	*id = v.(CcId160Ms)
+	return nil
+}
+
+func marshalJSON(b []byte, base byte) ([]byte, error) {
+	s, err := EncodeToBase(b, base)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]byte, 0, len(s)+2)
+	res = append(res, '"')
+	res = append(res, s...)
+	res = append(res, '"')
+	return res, nil
+}
+
+func unmarshalJSON(data []byte, layout ccIdLayout, fingerprintSize byte) (CcId, error) {
+	if string(data) == "null" {
+		return layout.nilCcId()
+	}
+	var s string
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return nil, JSONFieldError{Err: err, value: data}
+	}
+	id, err := decodeCcIdString(s, layout, fingerprintSize)
+	if err != nil {
+		return nil, JSONFieldError{Err: err, value: data}
+	}
+	return id, nil
+}
+
+// decodeCcIdString decodes a string in any supported base, the base is detected by the string length.
+func decodeCcIdString(s string, layout ccIdLayout, fingerprintSize byte) (CcId, error) {
+	base, err := detectBase(len(s), layout.size)
+	if err != nil {
+		return nil, err
+	}
+	b, err := DecodeFromBase(s, base)
+	if err != nil {
+		return nil, err
+	}
+	return layout.fromBytes(b, fingerprintSize)
+}
+
+// JSONCcId renders Id in JSON in the chosen base, e.g. a struct field rendered in base32:
+//
+//	type Order struct {
+//		Id JSONCcId `json:"id"`
+//	}
+//	order := Order{Id: JSONCcId{Id: id, Base: BASE32}}
+//
+// Nil CcIds of every type and nil Id are encoded as null.
+// Decoding accepts null and any base, the type and fingerprint size of the current Id are preserved.
+// Without Id a second precision CcId without fingerprint is decoded, the size is detected by the string length
+// in Base or in any base if Base is 0, e.g. 32 characters are base32 CcId160 unless Base is BASE16.
+type JSONCcId struct {
+	Id CcId
+	// Base is BASE62, BASE32 or BASE16. 0 - JSONBase of Id.
+	Base byte
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (v JSONCcId) MarshalJSON() ([]byte, error) {
+	if v.Id == nil || isNilCcId(v.Id) {
+		return []byte("null"), nil
+	}
+	base := v.Base
+	if base == 0 {
+		base = JSONBase(v.Id)
+	}
+	return marshalJSON(v.Id.Bytes(), base)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// Null keeps nil Id, otherwise it's decoded as nil CcId of the Id type.
+func (v *JSONCcId) UnmarshalJSON(data []byte) error {
+	if v.Id != nil {
+		id, err := unmarshalJSON(data, layoutOf(v.Id), byte(len(v.Id.Fingerprint())))
+		if err != nil {
+			return err
+		}
+		v.Id = id
+		return nil
+	}
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return JSONFieldError{Err: err, value: data}
+	}
+	size, err := detectSize(len(s), v.Base)
+	if err != nil {
+		return JSONFieldError{Err: err, value: data}
+	}
+	id, err := unmarshalJSON(data, ccIdLayout{size: size}, 0)
+	if err != nil {
+		return err
+	}
+	v.Id = id
+	return nil
+}
+
+// detectSize returns CcId size of a string of 'strSize' characters in 'base', bases are tried in order
+// BASE62, BASE32, BASE16 if 'base' is 0.
+func detectSize(strSize int, base byte) (byte, error) {
+	if strSize > MaxInputLength {
+		return 0, InputTooLongError(strSize)
+	}
+	l := byte(strSize)
+	switch base {
+	case BASE62:
+		return getBase62byteSliceSize(l)
+	case BASE32:
+		return getBase32byteSliceSize(l)
+	case BASE16:
+		return getBase16byteSliceSize(l)
+	case 0:
+		for _, b := range []byte{BASE62, BASE32, BASE16} {
+			if size, err := detectSize(strSize, b); err == nil {
+				return size, nil
+			}
+		}
+		return 0, InvalidLengthError(l)
+	}
+	return 0, InvalidBaseError(base)
+}
+
+func isNilCcId(id CcId) bool {
+	switch v := id.(type) {
+	case CcId96Ms:
+		return v.data == NilCcId96Ms.data
+	case CcId128Ms:
+		return v.data == NilCcId128Ms.data
+	case CcId160Ms:
+		return v.data == NilCcId160Ms.data
+	case CcId64:
+		return v.data == NilCcId64.data
+	case CcId96:
+		return v.data == NilCcId96.data
+	case CcId128:
+		return v.data == NilCcId128.data
+	case CcId160:
+		return v.data == NilCcId160.data
+	}
+	return false
+}
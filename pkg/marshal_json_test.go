@@ -0,0 +1,215 @@
+package pkg
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	groups := map[string]map[string]CcIdTestCases{
+		"ccid64":  TestCaseCcId64Map,
+		"ccid96":  TestCaseCcId96Map,
+		"ccid128": TestCaseCcId128Map,
+		"ccid160": TestCaseCcId160Map,
+	}
+	bases := []byte{BASE62, BASE32, BASE16}
+	for _, name := range SortKeys(groups) {
+		m := groups[name]
+		for _, key := range SortKeys(m) {
+			tc := m[key]
+			ctor, _ := ccIdCtorBySize(byte(len(tc.Bytes)))
+			id, _ := ctor(tc.timestamp, tc.Fingerprint, tc.payload)
+			want := map[byte]string{BASE62: tc.Base62, BASE32: tc.Base32, BASE16: tc.Base16}
+			for _, base := range bases {
+				t.Run(fmt.Sprintf("%s_%s_base%d", name, key, base), func(t *testing.T) {
+					got, err := json.Marshal(JSONCcId{Id: id, Base: base})
+					if err != nil {
+						t.Errorf("json.Marshal() error = %v", err)
+						return
+					}
+					if string(got) != `"`+want[base]+`"` {
+						t.Errorf("json.Marshal() =\n%s, want\n%q", got, want[base])
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestMarshalJSON_Null(t *testing.T) {
+	type dto struct {
+		A CcId64  `json:"a"`
+		B CcId96  `json:"b"`
+		C CcId128 `json:"c"`
+		D CcId160 `json:"d"`
+	}
+	b, err := json.Marshal(dto{})
+	if err != nil {
+		t.Errorf("json.Marshal() error = %v", err)
+		return
+	}
+	want := `{"a":null,"b":null,"c":null,"d":null}`
+	if string(b) != want {
+		t.Errorf("json.Marshal() =\n%s, want\n%s", b, want)
+	}
+	tc := TestCaseCcId96Map["max fingerprint"]
+	id, _ := NewCcId96WithFingerprint(tc.timestamp, tc.Fingerprint, tc.payload)
+	out := dto{B: id.(CcId96)}
+	err = json.Unmarshal(b, &out)
+	if err != nil {
+		t.Errorf("json.Unmarshal() error = %v", err)
+		return
+	}
+	if out != (dto{}) {
+		t.Errorf("json.Unmarshal() =\n%#v, want nil ids", out)
+	}
+}
+
+func TestUnmarshalJSON_AnyBase(t *testing.T) {
+	tc := TestCaseCcId128Map["max fingerprint"]
+	for _, s := range []string{tc.Base62, tc.Base32, tc.Base16} {
+		t.Run(s, func(t *testing.T) {
+			id, _ := NewCcId128WithFingerprint(0, tc.Fingerprint, make([]byte, ByteSliceSize128))
+			v := id.(CcId128)
+			err := json.Unmarshal([]byte(`"`+s+`"`), &v)
+			if err != nil {
+				t.Errorf("json.Unmarshal(%s) error = %v", s, err)
+				return
+			}
+			if fmt.Sprintf("%#v", v) != tc.GoString {
+				t.Errorf("json.Unmarshal(%s) =\n%#v, want\n%s", s, v, tc.GoString)
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSON_Error(t *testing.T) {
+	var id CcId64
+	var lengthErr InvalidLengthError
+	err := json.Unmarshal([]byte(`"0000"`), &id)
+	if !errors.As(err, &lengthErr) {
+		t.Errorf("json.Unmarshal() error = %v, want InvalidLengthError", err)
+	}
+	var charErr InvalidCharacterError
+	err = json.Unmarshal([]byte(`"00000*00000"`), &id)
+	if !errors.As(err, &charErr) || charErr.Pos != 5 {
+		t.Errorf("json.Unmarshal() error = %v, want InvalidCharacterError at 5", err)
+	}
+	err = json.Unmarshal([]byte(`12`), &id)
+	if err == nil {
+		t.Errorf("json.Unmarshal() error = nil, want error")
+	}
+}
+
+func TestMarshalJSON_Base62(t *testing.T) {
+	tc := TestCaseCcId160Map["max fingerprint"]
+	id, _ := NewCcId160Value(tc.timestamp, tc.Fingerprint, tc.payload)
+	got, err := json.Marshal(id)
+	if err != nil || string(got) != `"`+tc.Base62+`"` {
+		t.Errorf("json.Marshal() = %s, %v, want %q", got, err, tc.Base62)
+	}
+}
+
+func TestJSONCcId(t *testing.T) {
+	type dto struct {
+		A JSONCcId `json:"a"`
+		B JSONCcId `json:"b"`
+		C JSONCcId `json:"c,omitempty"`
+		D JSONCcId `json:"d"`
+	}
+	tc := TestCaseCcId96Map["max fingerprint"]
+	id, _ := NewCcId96WithFingerprint(tc.timestamp, tc.Fingerprint, tc.payload)
+	tcMs := TestCaseCcId128MsMap["fingerprint"]
+	idMs, _ := NewCcId128MsWithFingerprint(tcMs.timestamp, tcMs.Fingerprint, tcMs.payload)
+	in := dto{
+		A: JSONCcId{Id: id, Base: BASE32},
+		B: JSONCcId{Id: idMs, Base: BASE16},
+		C: JSONCcId{Id: id},
+		D: JSONCcId{Id: NilCcId128, Base: BASE32},
+	}
+	b, err := json.Marshal(in)
+	want := `{"a":"` + tc.Base32 + `","b":"` + tcMs.Base16 + `","c":"` + tc.Base62 + `","d":null}`
+	if err != nil || string(b) != want {
+		t.Fatalf("json.Marshal() =\n%s, %v, want\n%s", b, err, want)
+	}
+	fingerprintSize := make([]byte, len(tc.Fingerprint))
+	template, _ := NewCcId96WithFingerprint(0, fingerprintSize, make([]byte, ByteSliceSize96))
+	templateMs, _ := NewCcId128MsWithFingerprint(0, make([]byte, len(tcMs.Fingerprint)), make([]byte, ByteSliceSize128))
+	out := dto{A: JSONCcId{Id: template}, B: JSONCcId{Id: templateMs}, C: JSONCcId{Id: template}, D: JSONCcId{Id: id}}
+	err = json.Unmarshal(b, &out)
+	if err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if fmt.Sprintf("%#v", out.A.Id) != tc.GoString || fmt.Sprintf("%#v", out.C.Id) != tc.GoString {
+		t.Errorf("json.Unmarshal() =\n%#v\n%#v, want\n%s", out.A.Id, out.C.Id, tc.GoString)
+	}
+	if fmt.Sprintf("%#v", out.B.Id) != tcMs.GoString {
+		t.Errorf("json.Unmarshal() =\n%#v, want\n%s", out.B.Id, tcMs.GoString)
+	}
+	if out.D.Id != NilCcId96 {
+		t.Errorf("json.Unmarshal(null) = %#v, want NilCcId96", out.D.Id)
+	}
+}
+
+func TestJSONCcId_WithoutId(t *testing.T) {
+	tc := TestCaseCcId160Map["max fingerprint"]
+	tc128 := TestCaseCcId128Map["max fingerprint"]
+	cases := map[string]struct {
+		src  string
+		base byte
+		want []byte
+	}{
+		"base62":        {tc.Base62, 0, tc.Bytes},
+		"base32":        {tc.Base32, 0, tc.Bytes},
+		"base32 160":    {tc.Base32, BASE32, tc.Bytes},
+		"base16 128":    {tc128.Base16, BASE16, tc128.Bytes},
+		"base16 160":    {tc.Base16, 0, tc.Bytes},
+		"ccid64 base62": {TestCaseCcId64Map["max id"].Base62, BASE62, TestCaseCcId64Map["max id"].Bytes},
+	}
+	for _, key := range SortKeys(cases) {
+		c := cases[key]
+		t.Run(key, func(t *testing.T) {
+			v := JSONCcId{Base: c.base}
+			err := json.Unmarshal([]byte(`"`+c.src+`"`), &v)
+			if err != nil || v.Id == nil || !SliceEqual(v.Id.Bytes(), c.want) || len(v.Id.Fingerprint()) != 0 {
+				t.Errorf("json.Unmarshal(%s) = %#v, %v, want %x", c.src, v.Id, err, c.want)
+			}
+		})
+	}
+}
+
+func TestJSONCcId_Error(t *testing.T) {
+	id, _ := NewCcId64Value(1, nil, []byte{1, 2, 3, 4})
+	var baseErr InvalidBaseError
+	_, err := json.Marshal(JSONCcId{Id: id, Base: 10})
+	if !errors.As(err, &baseErr) {
+		t.Errorf("json.Marshal() error = %v, want InvalidBaseError", err)
+	}
+	var v JSONCcId
+	if err = json.Unmarshal([]byte(`null`), &v); err != nil || v.Id != nil {
+		t.Errorf("json.Unmarshal(null) = %v, %v, want nil", v.Id, err)
+	}
+	var lengthErr InvalidLengthError
+	err = json.Unmarshal([]byte(`"0000"`), &v)
+	if !errors.As(err, &lengthErr) || v.Id != nil {
+		t.Errorf("json.Unmarshal() without Id = %v, %v, want InvalidLengthError", v.Id, err)
+	}
+	v.Base = BASE16
+	err = json.Unmarshal([]byte(`"`+id.AsBase62()+`"`), &v)
+	if !errors.As(err, &lengthErr) || v.Id != nil {
+		t.Errorf("json.Unmarshal() base62 as base16 = %v, %v, want InvalidLengthError", v.Id, err)
+	}
+	v.Base = 10
+	err = json.Unmarshal([]byte(`"`+id.AsBase62()+`"`), &v)
+	if !errors.As(err, &baseErr) {
+		t.Errorf("json.Unmarshal() error = %v, want InvalidBaseError", err)
+	}
+	v.Id = NilCcId64
+	var charErr InvalidCharacterError
+	err = json.Unmarshal([]byte(`"00000*00000"`), &v)
+	if !errors.As(err, &charErr) || charErr.Pos != 5 {
+		t.Errorf("json.Unmarshal() error = %v, want InvalidCharacterError at 5", err)
+	}
+}
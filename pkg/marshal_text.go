@@ -0,0 +1,146 @@
+package pkg
+
+// MarshalText implements encoding.TextMarshaler interface.
+// CcId is encoded as base62 string.
+func (id CcId64) MarshalText() ([]byte, error) {
+	return marshalText(id.data[:])
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+// It expects base62 string, fingerprint size of the receiver is preserved.
+func (id *CcId64) UnmarshalText(text []byte) error {
+	v, err := unmarshalText(text, ccIdLayout{size: ByteSliceSize64}, id.fingerprintSize)
+	if err != nil {
+		return err
+	}
+	*id = v.(CcId64)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+// CcId is encoded as base62 string.
+func (id CcId96) MarshalText() ([]byte, error) {
+	return marshalText(id.data[:])
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+// It expects base62 string, fingerprint size of the receiver is preserved.
+func (id *CcId96) UnmarshalText(text []byte) error {
+	v, err := unmarshalText(text, ccIdLayout{size: ByteSliceSize96}, id.fingerprintSize)
+	if err != nil {
+		return err
+	}
+	*id = v.(CcId96)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+// CcId is encoded as base62 string.
+func (id CcId128) MarshalText() ([]byte, error) {
+	return marshalText(id.data[:])
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+// It expects base62 string, fingerprint size of the receiver is preserved.
+func (id *CcId128) UnmarshalText(text []byte) error {
+	v, err := unmarshalText(text, ccIdLayout{size: ByteSliceSize128}, id.fingerprintSize)
+	if err != nil {
+		return err
+	}
+	*id = v.(CcId128)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+// CcId is encoded as base62 string.
+func (id CcId160) MarshalText() ([]byte, error) {
+	return marshalText(id.data[:])
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+// It expects base62 string, fingerprint size of the receiver is preserved.
+func (id *CcId160) UnmarshalText(text []byte) error {
+	v, err := unmarshalText(text, ccIdLayout{size: ByteSliceSize160}, id.fingerprintSize)
+	if err != nil {
+		return err
+	}
+	*id = v.(CcId160)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+// CcId is encoded as base62 string.
+func (id CcId96Ms) MarshalText() ([]byte, error) {
+	return marshalText(id.data[:])
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+// It expects base62 string, fingerprint size of the receiver is preserved.
+func (id *CcId96Ms) UnmarshalText(text []byte) error {
+	v, err := unmarshalText(text, ccIdLayout{size: ByteSliceSize96, millisecond: true}, id.fingerprintSize)
+	if err != nil {
+		return err
+	}
+	*id = v.(CcId96Ms)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+// CcId is encoded as base62 string.
+func (id CcId128Ms) MarshalText() ([]byte, error) {
+	return marshalText(id.data[:])
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+// It expects base62 string, fingerprint size of the receiver is preserved.
+func (id *CcId128Ms) UnmarshalText(text []byte) error {
+	v, err := unmarshalText(text, ccIdLayout{size: ByteSliceSize128, millisecond: true}, id.fingerprintSize)
+	if err != nil {
+		return err
+	}
+	*id = v.(CcId128Ms)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+// CcId is encoded as base62 string.
+func (id CcId160Ms) MarshalText() ([]byte, error) {
+	return marshalText(id.data[:])
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+// It expects base62 string, fingerprint size of the receiver is preserved.
+func (id *CcId160Ms) UnmarshalText(text []byte) error {
+	v, err := unmarshalText(text, ccIdLayout{size: ByteSliceSize160, millisecond: true}, id.fingerprintSize)
+	if err != nil {
+		return err
+	}
+	*id = v.(CcId160Ms)
+	return nil
+}
+
+func marshalText(b []byte) ([]byte, error) {
+	size, err := getBase62strSize(byte(len(b)))
+	if err != nil {
+		return nil, err
+	}
+	res := make([]byte, size)
+	asBase62(b, res, base62Alphabet)
+	return res, nil
+}
+
+func unmarshalText(text []byte, layout ccIdLayout, fingerprintSize byte) (CcId, error) {
+	strSize, err := getBase62strSize(layout.size)
+	if err != nil {
+		return nil, err
+	}
+	l := len(text)
+	if l != int(strSize) {
+		return nil, InvalidLengthError(byte(l))
+	}
+	b, err := DecodeFromBase62(string(text))
+	if err != nil {
+		return nil, err
+	}
+	return layout.fromBytes(b, fingerprintSize)
+}
@@ -0,0 +1,148 @@
+package pkg
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type textMarshalTestCase struct {
+	name   string
+	cases  map[string]CcIdTestCases
+	ctor   CcIdCtor
+	newPtr func(v CcId) encoding.TextUnmarshaler
+}
+
+var textMarshalTestCases = []textMarshalTestCase{
+	{"ccid64", TestCaseCcId64Map, NewCcId64WithFingerprint, func(v CcId) encoding.TextUnmarshaler { id, _ := v.(CcId64); return &id }},
+	{"ccid96", TestCaseCcId96Map, NewCcId96WithFingerprint, func(v CcId) encoding.TextUnmarshaler { id, _ := v.(CcId96); return &id }},
+	{"ccid128", TestCaseCcId128Map, NewCcId128WithFingerprint, func(v CcId) encoding.TextUnmarshaler { id, _ := v.(CcId128); return &id }},
+	{"ccid160", TestCaseCcId160Map, NewCcId160WithFingerprint, func(v CcId) encoding.TextUnmarshaler { id, _ := v.(CcId160); return &id }},
+}
+
+func TestMarshalText(t *testing.T) {
+	for _, group := range textMarshalTestCases {
+		t.Run(group.name, func(t *testing.T) {
+			keys := SortKeys(group.cases)
+			for _, key := range keys {
+				tc := group.cases[key]
+				t.Run(key, func(t *testing.T) {
+					id, _ := group.ctor(tc.timestamp, tc.Fingerprint, tc.payload)
+					got, err := id.(encoding.TextMarshaler).MarshalText()
+					if err != nil {
+						t.Errorf("MarshalText() error = %v", err)
+						return
+					}
+					if string(got) != tc.Base62 {
+						t.Errorf("MarshalText() =\n%s, want\n%s", got, tc.Base62)
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestUnmarshalText(t *testing.T) {
+	for _, group := range textMarshalTestCases {
+		t.Run(group.name, func(t *testing.T) {
+			keys := SortKeys(group.cases)
+			for _, key := range keys {
+				tc := group.cases[key]
+				t.Run(key, func(t *testing.T) {
+					// receiver with the same layout keeps fingerprint size
+					layout, _ := group.ctor(0, make([]byte, len(tc.Fingerprint)), make([]byte, ByteSliceSize160))
+					ptr := group.newPtr(layout)
+					err := ptr.UnmarshalText([]byte(tc.Base62))
+					if err != nil {
+						t.Errorf("UnmarshalText(%s) error = %v", tc.Base62, err)
+						return
+					}
+					v := fmt.Sprintf("%#v", ptr)
+					if v != tc.GoString {
+						t.Errorf("UnmarshalText(%s) =\n%s, want\n%s", tc.Base62, v, tc.GoString)
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestUnmarshalText_Error(t *testing.T) {
+	for _, group := range textMarshalTestCases {
+		t.Run(group.name, func(t *testing.T) {
+			keys := SortKeys(group.cases)
+			tc := group.cases[keys[0]]
+			t.Run("larger", func(t *testing.T) {
+				var lengthErr InvalidLengthError
+				err := group.newPtr(nil).UnmarshalText([]byte(tc.Base62 + "0"))
+				if !errors.As(err, &lengthErr) || int(lengthErr) != len(tc.Base62)+1 {
+					t.Errorf("UnmarshalText() error = %v, want InvalidLengthError", err)
+				}
+			})
+			t.Run("smaller", func(t *testing.T) {
+				var lengthErr InvalidLengthError
+				err := group.newPtr(nil).UnmarshalText([]byte(tc.Base62[1:]))
+				if !errors.As(err, &lengthErr) {
+					t.Errorf("UnmarshalText() error = %v, want InvalidLengthError", err)
+				}
+			})
+			t.Run("other size", func(t *testing.T) {
+				var lengthErr InvalidLengthError
+				err := group.newPtr(nil).UnmarshalText([]byte(strings.Repeat("0", Base62strSize160+1)))
+				if !errors.As(err, &lengthErr) {
+					t.Errorf("UnmarshalText() error = %v, want InvalidLengthError", err)
+				}
+			})
+			t.Run("invalid character", func(t *testing.T) {
+				var charErr InvalidCharacterError
+				v := []byte(tc.Base62)
+				v[3] = '*'
+				err := group.newPtr(nil).UnmarshalText(v)
+				if !errors.As(err, &charErr) || charErr.Pos != 3 || charErr.Character != '*' {
+					t.Errorf("UnmarshalText(%s) error = %v, want InvalidCharacterError at 3", v, err)
+				}
+			})
+		})
+	}
+}
+
+func TestMarshalText_Json(t *testing.T) {
+	type dto struct {
+		Id    CcId96            `json:"id"`
+		Index map[CcId64]string `json:"index"`
+	}
+	tc96 := TestCaseCcId96Map["max id"]
+	tc64 := TestCaseCcId64Map["max id"]
+	id96, _ := NewCcId96WithFingerprint(tc96.timestamp, tc96.Fingerprint, tc96.payload)
+	id64, _ := NewCcId64WithFingerprint(tc64.timestamp, tc64.Fingerprint, tc64.payload)
+	in := dto{Id: id96.(CcId96), Index: map[CcId64]string{id64.(CcId64): "value"}}
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Errorf("json.Marshal() error = %v", err)
+		return
+	}
+	want := fmt.Sprintf(`{"id":"%s","index":{"%s":"value"}}`, tc96.Base62, tc64.Base62)
+	if string(b) != want {
+		t.Errorf("json.Marshal() =\n%s, want\n%s", b, want)
+	}
+	var out dto
+	err = json.Unmarshal(b, &out)
+	if err != nil {
+		t.Errorf("json.Unmarshal() error = %v", err)
+		return
+	}
+	if !SliceEqual(out.Id.Bytes(), in.Id.Bytes()) {
+		t.Errorf("json.Unmarshal() id =\n%x, want\n%x", out.Id.Bytes(), in.Id.Bytes())
+	}
+	if out.Index[NilCcId64] != "" || len(out.Index) != 1 {
+		t.Errorf("json.Unmarshal() index = %v", out.Index)
+	}
+	for k := range out.Index {
+		if !SliceEqual(k.Bytes(), tc64.Bytes) {
+			t.Errorf("json.Unmarshal() key =\n%x, want\n%x", k.Bytes(), tc64.Bytes)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package pkg
+
+// ParseInto decodes base62, base32 or base16 'src' into 'dst' without allocations.
+// The base is defined by the length of 'src', the lengths are different for every CcId size.
+// Fingerprint size of 'dst' is kept, so 'dst' can be prepared once and reused,
+// e.g. created by NewCcId128Value with the fingerprint. 'dst' isn't changed on error.
+func ParseInto[T CcId64 | CcId96 | CcId128 | CcId160 | CcId96Ms | CcId128Ms | CcId160Ms, S string | []byte](dst *T, src S) error {
+	var data []byte
+	switch v := any(dst).(type) {
+	case *CcId64:
+		data = v.data[:]
+	case *CcId96:
+		data = v.data[:]
+	case *CcId128:
+		data = v.data[:]
+	case *CcId160:
+		data = v.data[:]
+	case *CcId96Ms:
+		data = v.data[:]
+	case *CcId128Ms:
+		data = v.data[:]
+	case *CcId160Ms:
+		data = v.data[:]
+	}
+	size := byte(len(data))
+	base62Size, _ := getBase62strSize(size)
+	base32Size, _ := getBase32strSize(size)
+	base16Size, _ := getBase16strSize(size)
+	var buf [ByteSliceSize160]byte
+	res := buf[:size]
+	var err error
+	switch len(src) {
+	case int(base62Size):
+		err = fromBase62(src, res)
+	case int(base32Size):
+		err = fromBase32(src, res)
+	case int(base16Size):
+		err = fromBase16(src, res)
+	default:
+		return InvalidLengthError(byte(len(src)))
+	}
+	if err != nil {
+		return err
+	}
+	copy(data, res)
+	return nil
+}
@@ -0,0 +1,96 @@
+package pkg
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseInto(t *testing.T) {
+	keys := SortKeys(TestCaseCcId128Map)
+	for _, key := range keys {
+		tc := TestCaseCcId128Map[key]
+		t.Run(key, func(t *testing.T) {
+			id, _ := NewCcId128Value(tc.timestamp, tc.Fingerprint, tc.payload)
+			cases := map[string][]byte{
+				"base62": id.AppendBase62(nil),
+				"base32": id.AppendBase32(nil),
+				"base16": id.AppendBase16(nil),
+			}
+			want := map[string]string{"base62": tc.Base62, "base32": tc.Base32, "base16": tc.Base16}
+			for _, base := range SortKeys(cases) {
+				src := cases[base]
+				if string(src) != want[base] {
+					t.Errorf("AppendBase%s() = %s, want %s", base[4:], src, want[base])
+				}
+				dst, _ := NewCcId128Value(0, tc.Fingerprint, tc.payload)
+				err := ParseInto(&dst, src)
+				if err != nil || dst != id {
+					t.Errorf("ParseInto(%s) = %x, %v, want %x", src, dst.Bytes(), err, id.Bytes())
+				}
+				dst = NilCcId128
+				err = ParseInto(&dst, string(src))
+				if err != nil || !SliceEqual(dst.Bytes(), tc.Bytes) {
+					t.Errorf("ParseInto(%q) = %x, %v, want %x", src, dst.Bytes(), err, tc.Bytes)
+				}
+			}
+		})
+	}
+}
+
+func TestParseInto_Ms(t *testing.T) {
+	tc := TestCaseCcId160MsMap["fingerprint"]
+	id, _ := NewCcId160MsValue(tc.timestamp, tc.Fingerprint, tc.payload)
+	dst, _ := NewCcId160MsValue(0, tc.Fingerprint, tc.payload)
+	err := ParseInto(&dst, tc.Base32)
+	if err != nil || dst != id || !dst.Time().Equal(id.Time()) {
+		t.Errorf("ParseInto(%s) = %x %s, %v, want %x %s", tc.Base32, dst.Bytes(), dst.Time(), err, id.Bytes(), id.Time())
+	}
+}
+
+func TestParseInto_Error(t *testing.T) {
+	tc := TestCaseCcId96Map["some id fingerprint"]
+	cases := map[string]struct {
+		src    string
+		target error
+	}{
+		"empty":           {"", InvalidLengthError(0)},
+		"other size":      {TestCaseCcId128Map["some id"].Base62, InvalidLengthError(Base62strSize128)},
+		"base62 char":     {"-" + tc.Base62[1:], InvalidCharacterError{'-', 0}},
+		"base32 char":     {tc.Base32[:19] + "U", InvalidCharacterError{'U', 19}},
+		"base16 char":     {"g" + tc.Base16[1:], InvalidCharacterError{'g', 0}},
+		"base32 overflow": {"Z" + tc.Base32[1:], OverflowError(ByteSliceSize96)},
+	}
+	for _, key := range SortKeys(cases) {
+		c := cases[key]
+		t.Run(key, func(t *testing.T) {
+			dst, _ := NewCcId96Value(tc.timestamp, tc.Fingerprint, tc.payload)
+			want := dst
+			err := ParseInto(&dst, []byte(c.src))
+			if !errors.Is(err, c.target) {
+				t.Errorf("ParseInto(%s) error = %v, want %v", c.src, err, c.target)
+			}
+			if dst != want {
+				t.Errorf("ParseInto(%s) changed dst to %x, want %x", c.src, dst.Bytes(), want.Bytes())
+			}
+		})
+	}
+}
+
+func TestParseInto_Allocs(t *testing.T) {
+	tc := TestCaseCcId160Map["some id fingerprint"]
+	id, _ := NewCcId160Value(tc.timestamp, tc.Fingerprint, tc.payload)
+	buf := make([]byte, 0, Base16strSize160)
+	src := []byte(tc.Base62)
+	var dst CcId160
+	cases := map[string]func(){
+		"AppendBase62": func() { buf = id.AppendBase62(buf[:0]) },
+		"AppendBase32": func() { buf = id.AppendBase32(buf[:0]) },
+		"AppendBase16": func() { buf = id.AppendBase16(buf[:0]) },
+		"ParseInto":    func() { _ = ParseInto(&dst, src) },
+	}
+	for _, key := range SortKeys(cases) {
+		if n := testing.AllocsPerRun(100, cases[key]); n != 0 {
+			t.Errorf("%s allocs = %v, want 0", key, n)
+		}
+	}
+}
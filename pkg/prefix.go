@@ -0,0 +1,174 @@
+package pkg
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const (
+	// PrefixSeparator separates type prefix and CcId string, e.g. "ord_3xK9..."
+	PrefixSeparator = '_'
+	// MaxPrefixSize is the max length of a type prefix.
+	MaxPrefixSize = 63
+)
+
+// PrefixSpec describes CcIds registered for a type prefix.
+type PrefixSpec struct {
+	// Size of the CcId in bytes.
+	Size byte
+	// Base of the CcId string, BASE62, BASE32 or BASE16. 0 means BASE62.
+	Base byte
+	// FingerprintSize of the CcId in bytes.
+	FingerprintSize byte
+	// Millisecond is true for CcId96Ms, CcId128Ms and CcId160Ms.
+	Millisecond bool
+}
+
+func (s PrefixSpec) layout() ccIdLayout {
+	return ccIdLayout{size: s.Size, millisecond: s.Millisecond}
+}
+
+// PrefixRegistry maps human-visible type prefixes to CcId layouts, e.g. "ord" for orders and "usr" for users.
+// It's safe for concurrent use.
+type PrefixRegistry struct {
+	mu    sync.RWMutex
+	specs map[string]PrefixSpec
+}
+
+// NewPrefixRegistry creates an empty PrefixRegistry.
+func NewPrefixRegistry() *PrefixRegistry {
+	return &PrefixRegistry{specs: map[string]PrefixSpec{}}
+}
+
+// Register adds a prefix to the registry.
+// 'prefix' must be lowercase latin letters, digits and underscores, starting with a letter and not ending with underscore.
+// 'spec' must describe a valid CcId layout.
+func (r *PrefixRegistry) Register(prefix string, spec PrefixSpec) error {
+	if !isValidPrefix(prefix) {
+		return InvalidPrefixError(prefix)
+	}
+	if spec.Base == 0 {
+		spec.Base = BASE62
+	}
+	if !isValidBase(spec.Base) {
+		return InvalidBaseError(spec.Base)
+	}
+	if _, err := selfDescribingLayoutIdx(spec.layout(), spec.FingerprintSize); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.specs[prefix]; ok {
+		return DuplicatePrefixError(prefix)
+	}
+	r.specs[prefix] = spec
+	return nil
+}
+
+// Lookup returns the spec registered for the prefix.
+func (r *PrefixRegistry) Lookup(prefix string) (PrefixSpec, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	spec, ok := r.specs[prefix]
+	return spec, ok
+}
+
+// Format returns CcId as "prefix_<CcId string>".
+// CcId size and fingerprint size must match the registered spec.
+func (r *PrefixRegistry) Format(prefix string, id CcId) (string, error) {
+	spec, ok := r.Lookup(prefix)
+	if !ok {
+		return "", UnknownPrefixError(prefix)
+	}
+	if id.Size() != spec.Size {
+		return "", InvalidLengthError(id.Size())
+	}
+	if layoutOf(id).millisecond != spec.Millisecond {
+		return "", InvalidTimestampPrecisionError(spec.Millisecond)
+	}
+	if fingerprintSize := byte(len(id.Fingerprint())); fingerprintSize != spec.FingerprintSize {
+		return "", InvalidFingerprintSizeError{
+			ProvidedSize: fingerprintSize,
+			RequiredSize: spec.FingerprintSize,
+		}
+	}
+	body, err := EncodeToBase(id.Bytes(), spec.Base)
+	if err != nil {
+		return "", err
+	}
+	return prefix + string(PrefixSeparator) + body, nil
+}
+
+// Parse splits "prefix_<CcId string>" and creates the CcId according to the spec registered for the prefix.
+func (r *PrefixRegistry) Parse(s string) (string, CcId, error) {
+	idx := strings.LastIndexByte(s, PrefixSeparator)
+	if idx < 0 {
+		return "", nil, InvalidPrefixError("")
+	}
+	prefix := s[:idx]
+	spec, ok := r.Lookup(prefix)
+	if !ok {
+		return prefix, nil, UnknownPrefixError(prefix)
+	}
+	b, err := DecodeFromBase(s[idx+1:], spec.Base)
+	if err != nil {
+		return prefix, nil, err
+	}
+	if byte(len(b)) != spec.Size {
+		return prefix, nil, InvalidLengthError(byte(len(s) - idx - 1))
+	}
+	id, err := spec.layout().fromBytes(b, spec.FingerprintSize)
+	return prefix, id, err
+}
+
+// ParseWithPrefix is like Parse but also validates that the string has the expected prefix.
+// It returns PrefixMismatchError if the prefix is different, e.g. user id passed instead of order id.
+func (r *PrefixRegistry) ParseWithPrefix(s string, prefix string) (CcId, error) {
+	actual, id, err := r.Parse(s)
+	if actual != prefix {
+		return nil, PrefixMismatchError{Expected: prefix, Actual: actual}
+	}
+	return id, err
+}
+
+type InvalidPrefixError string
+
+func (e InvalidPrefixError) Error() string {
+	return fmt.Sprintf("CCID: invalid prefix %q", string(e))
+}
+
+type UnknownPrefixError string
+
+func (e UnknownPrefixError) Error() string {
+	return fmt.Sprintf("CCID: unknown prefix %q", string(e))
+}
+
+type DuplicatePrefixError string
+
+func (e DuplicatePrefixError) Error() string {
+	return fmt.Sprintf("CCID: prefix %q already registered", string(e))
+}
+
+type PrefixMismatchError struct {
+	Expected string
+	Actual   string
+}
+
+func (e PrefixMismatchError) Error() string {
+	return fmt.Sprintf("CCID: prefix mismatch %q, expected %q", e.Actual, e.Expected)
+}
+
+func isValidPrefix(prefix string) bool {
+	l := len(prefix)
+	if l == 0 || l > MaxPrefixSize || prefix[0] < 'a' || prefix[0] > 'z' || prefix[l-1] == PrefixSeparator {
+		return false
+	}
+	for i := 1; i < l; i++ {
+		c := prefix[i]
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != PrefixSeparator {
+			return false
+		}
+	}
+	return true
+}
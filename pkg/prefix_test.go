@@ -0,0 +1,153 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func newTestPrefixRegistry() *PrefixRegistry {
+	r := NewPrefixRegistry()
+	_ = r.Register("ord", PrefixSpec{Size: ByteSliceSize96, FingerprintSize: 3})
+	_ = r.Register("usr", PrefixSpec{Size: ByteSliceSize64})
+	_ = r.Register("sk_live", PrefixSpec{Size: ByteSliceSize160, Base: BASE32, FingerprintSize: 5})
+	_ = r.Register("evt", PrefixSpec{Size: ByteSliceSize128, FingerprintSize: 3, Millisecond: true})
+	return r
+}
+
+func TestPrefixRegistry_Register(t *testing.T) {
+	r := newTestPrefixRegistry()
+	var prefixErr InvalidPrefixError
+	var duplicateErr DuplicatePrefixError
+	var baseErr InvalidBaseError
+	var lengthErr InvalidLengthError
+	var fpErr InvalidFingerprintSizeError
+	cases := map[string]struct {
+		prefix string
+		spec   PrefixSpec
+		target any
+	}{
+		"empty":             {"", PrefixSpec{Size: ByteSliceSize64}, &prefixErr},
+		"upper case":        {"Ord", PrefixSpec{Size: ByteSliceSize64}, &prefixErr},
+		"digit first":       {"1ord", PrefixSpec{Size: ByteSliceSize64}, &prefixErr},
+		"separator last":    {"ord_", PrefixSpec{Size: ByteSliceSize64}, &prefixErr},
+		"dash":              {"ord-x", PrefixSpec{Size: ByteSliceSize64}, &prefixErr},
+		"duplicate":         {"ord", PrefixSpec{Size: ByteSliceSize64}, &duplicateErr},
+		"base":              {"inv", PrefixSpec{Size: ByteSliceSize64, Base: 10}, &baseErr},
+		"size":              {"inv", PrefixSpec{Size: 10}, &lengthErr},
+		"large fingerprint": {"inv", PrefixSpec{Size: ByteSliceSize64, FingerprintSize: 2}, &fpErr},
+		"ms size":           {"inv", PrefixSpec{Size: ByteSliceSize64, Millisecond: true}, &lengthErr},
+	}
+	for _, key := range SortKeys(cases) {
+		c := cases[key]
+		t.Run(key, func(t *testing.T) {
+			err := r.Register(c.prefix, c.spec)
+			if !errors.As(err, c.target) {
+				t.Errorf("Register(%q) error = %v, want %T", c.prefix, err, c.target)
+			}
+		})
+	}
+	spec, ok := r.Lookup("ord")
+	if !ok || spec.Base != BASE62 {
+		t.Errorf("Lookup(ord) = %#v, %t, want base62 spec", spec, ok)
+	}
+}
+
+func TestPrefixRegistry_FormatParse(t *testing.T) {
+	r := newTestPrefixRegistry()
+	tc96 := TestCaseCcId96Map["some id fingerprint"]
+	tc64 := TestCaseCcId64Map["some id"]
+	tc160 := TestCaseCcId160Map["max fingerprint"]
+	id96, _ := NewCcId96WithFingerprint(tc96.timestamp, tc96.Fingerprint, tc96.payload)
+	id64, _ := NewCcId64WithFingerprint(tc64.timestamp, tc64.Fingerprint, tc64.payload)
+	id160, _ := NewCcId160WithFingerprint(tc160.timestamp, tc160.Fingerprint, tc160.payload)
+	tc128ms := TestCaseCcId128MsMap["fingerprint"]
+	id128ms, _ := NewCcId128MsWithFingerprint(tc128ms.timestamp, tc128ms.Fingerprint, tc128ms.payload)
+	cases := map[string]struct {
+		id       CcId
+		str      string
+		goString string
+	}{
+		"ord":     {id96, "ord_" + tc96.Base62, tc96.GoString},
+		"usr":     {id64, "usr_" + tc64.Base62, tc64.GoString},
+		"sk_live": {id160, "sk_live_" + tc160.Base32, tc160.GoString},
+		"evt":     {id128ms, "evt_" + tc128ms.Base62, tc128ms.GoString},
+	}
+	for _, prefix := range SortKeys(cases) {
+		c := cases[prefix]
+		t.Run(prefix, func(t *testing.T) {
+			s, err := r.Format(prefix, c.id)
+			if err != nil || s != c.str {
+				t.Errorf("Format(%s) = %s, %v, want %s", prefix, s, err, c.str)
+				return
+			}
+			gotPrefix, got, err := r.Parse(s)
+			if err != nil || gotPrefix != prefix {
+				t.Errorf("Parse(%s) = %s, %v, want %s", s, gotPrefix, err, prefix)
+				return
+			}
+			if v := fmt.Sprintf("%#v", got); v != c.goString {
+				t.Errorf("Parse(%s) =\n%s, want\n%s", s, v, c.goString)
+			}
+			got, err = r.ParseWithPrefix(s, prefix)
+			if err != nil || !Equal(got, c.id) {
+				t.Errorf("ParseWithPrefix(%s) = %v, %v, want %v", s, got, err, c.id)
+			}
+		})
+	}
+}
+
+func TestPrefixRegistry_Error(t *testing.T) {
+	r := newTestPrefixRegistry()
+	id96, _ := NewCcId96WithFingerprint(1, []byte{1, 2, 3}, make([]byte, 5))
+	id96NoFp, _ := NewCcId96WithFingerprint(1, nil, make([]byte, 8))
+	ord, _ := r.Format("ord", id96)
+	t.Run("format", func(t *testing.T) {
+		var unknownErr UnknownPrefixError
+		if _, err := r.Format("inv", id96); !errors.As(err, &unknownErr) {
+			t.Errorf("Format(inv) error = %v, want UnknownPrefixError", err)
+		}
+		var lengthErr InvalidLengthError
+		if _, err := r.Format("usr", id96); !errors.As(err, &lengthErr) {
+			t.Errorf("Format(usr) error = %v, want InvalidLengthError", err)
+		}
+		var fpErr InvalidFingerprintSizeError
+		if _, err := r.Format("ord", id96NoFp); !errors.As(err, &fpErr) {
+			t.Errorf("Format(ord) error = %v, want InvalidFingerprintSizeError", err)
+		}
+		var precisionErr InvalidTimestampPrecisionError
+		id128, _ := NewCcId128WithFingerprint(1, []byte{1, 2, 3}, make([]byte, 9))
+		if _, err := r.Format("evt", id128); !errors.As(err, &precisionErr) {
+			t.Errorf("Format(evt) error = %v, want InvalidTimestampPrecisionError", err)
+		}
+	})
+	var prefixErr InvalidPrefixError
+	var unknownErr UnknownPrefixError
+	var lengthErr InvalidLengthError
+	var charErr InvalidCharacterError
+	cases := map[string]struct {
+		s      string
+		target any
+	}{
+		"no prefix":     {ord[4:], &prefixErr},
+		"unknown":       {"inv" + ord[3:], &unknownErr},
+		"size mismatch": {"usr" + ord[3:], &lengthErr},
+		"bad character": {ord[:6] + "*" + ord[7:], &charErr},
+	}
+	for _, key := range SortKeys(cases) {
+		c := cases[key]
+		t.Run(key, func(t *testing.T) {
+			_, _, err := r.Parse(c.s)
+			if !errors.As(err, c.target) {
+				t.Errorf("Parse(%s) error = %v, want %T", c.s, err, c.target)
+			}
+		})
+	}
+	t.Run("mismatch", func(t *testing.T) {
+		var mismatchErr PrefixMismatchError
+		_, err := r.ParseWithPrefix(ord, "usr")
+		if !errors.As(err, &mismatchErr) || mismatchErr.Actual != "ord" || mismatchErr.Expected != "usr" {
+			t.Errorf("ParseWithPrefix(%s, usr) error = %v, want PrefixMismatchError", ord, err)
+		}
+	})
+}
@@ -0,0 +1,124 @@
+package pkg
+
+// Self-describing format prefixes CcId string with a single header character from base62 alphabet.
+// Header index = base index * 20 + layout index, where
+// base index: 0 - base62, 1 - base32, 2 - base16
+// layout index: 0-1 - CcId64 with fingerprint size 0-1, 2-7 - CcId96 with fingerprint size 0-5,
+// 8-13 - CcId128 with fingerprint size 0-5, 14-19 - CcId160 with fingerprint size 0-5
+// Millisecond CcIds use the extension header character 'y' followed by a second header character.
+// Its index = base index * 18 + layout index, where
+// layout index: 0-5 - CcId96Ms, 6-11 - CcId128Ms, 12-17 - CcId160Ms with fingerprint size 0-5
+const (
+	selfDescribingLayouts   = 20
+	selfDescribingMsLayouts = 18
+	selfDescribingMsHeader  = 60
+)
+
+var selfDescribingBases = [3]byte{BASE62, BASE32, BASE16}
+
+// FormatSelfDescribing returns CcId as a string with a header character recording base, size and fingerprint size.
+// The string can be parsed by ParseSelfDescribing without any extra arguments.
+// 'base' must be BASE62, BASE32 or BASE16.
+func FormatSelfDescribing(id CcId, base byte) (string, error) {
+	baseIdx := -1
+	for i, v := range selfDescribingBases {
+		if v == base {
+			baseIdx = i
+		}
+	}
+	if baseIdx < 0 {
+		return "", InvalidBaseError(base)
+	}
+	layout := layoutOf(id)
+	layoutIdx, err := selfDescribingLayoutIdx(layout, byte(len(id.Fingerprint())))
+	if err != nil {
+		return "", err
+	}
+	body, err := EncodeToBase(id.Bytes(), base)
+	if err != nil {
+		return "", err
+	}
+	if layout.millisecond {
+		header := base62Alphabet[baseIdx*selfDescribingMsLayouts+layoutIdx]
+		return string([]byte{base62Alphabet[selfDescribingMsHeader], header}) + body, nil
+	}
+	return string(base62Alphabet[baseIdx*selfDescribingLayouts+layoutIdx]) + body, nil
+}
+
+// ParseSelfDescribing creates a CcId from a string produced by FormatSelfDescribing.
+// Size, fingerprint size and base are taken from the header character.
+func ParseSelfDescribing(s string) (CcId, error) {
+	if len(s) == 0 {
+		return nil, InvalidLengthError(0)
+	}
+	headerSize := 1
+	layoutCount := selfDescribingLayouts
+	idx := reverseBase62Table[s[0]]
+	if idx == selfDescribingMsHeader {
+		if len(s) < 2 {
+			return nil, InvalidLengthError(byte(len(s)))
+		}
+		headerSize = 2
+		layoutCount = selfDescribingMsLayouts
+		idx = reverseBase62Table[s[1]]
+	}
+	if idx == 0xff || int(idx) >= len(selfDescribingBases)*layoutCount {
+		return nil, InvalidHeaderError(s[headerSize-1])
+	}
+	base := selfDescribingBases[int(idx)/layoutCount]
+	layout, fingerprintSize := selfDescribingLayout(int(idx)%layoutCount, headerSize == 2)
+	b, err := DecodeFromBase(s[headerSize:], base)
+	if err != nil {
+		if e, ok := err.(InvalidCharacterError); ok {
+			e.Pos += byte(headerSize)
+			return nil, e
+		}
+		return nil, err
+	}
+	if byte(len(b)) != layout.size {
+		return nil, InvalidLengthError(byte(len(s)))
+	}
+	return layout.fromBytes(b, fingerprintSize)
+}
+
+func selfDescribingLayoutIdx(layout ccIdLayout, fingerprintSize byte) (int, error) {
+	maxFingerprintSize := byte(MaxFingerprintSize)
+	offset := 0
+	switch layout.size {
+	case ByteSliceSize64:
+		if layout.millisecond {
+			return 0, InvalidLengthError(layout.size)
+		}
+		maxFingerprintSize = MaxFingerprintSize64
+	case ByteSliceSize96:
+		offset = 2
+	case ByteSliceSize128:
+		offset = 8
+	case ByteSliceSize160:
+		offset = 14
+	default:
+		return 0, InvalidLengthError(layout.size)
+	}
+	if fingerprintSize > maxFingerprintSize {
+		return 0, InvalidFingerprintSizeError{
+			ProvidedSize: fingerprintSize,
+			RequiredSize: maxFingerprintSize,
+		}
+	}
+	if layout.millisecond {
+		offset -= 2
+	}
+	return offset + int(fingerprintSize), nil
+}
+
+func selfDescribingLayout(idx int, millisecond bool) (ccIdLayout, byte) {
+	if !millisecond {
+		if idx < 2 {
+			return ccIdLayout{size: ByteSliceSize64}, byte(idx)
+		}
+		idx -= 2
+	}
+	sizes := [3]byte{ByteSliceSize96, ByteSliceSize128, ByteSliceSize160}
+	layout := ccIdLayout{size: sizes[idx/(MaxFingerprintSize+1)], millisecond: millisecond}
+	return layout, byte(idx % (MaxFingerprintSize + 1))
+}
@@ -0,0 +1,154 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestSelfDescribing(t *testing.T) {
+	groups := map[string]map[string]CcIdTestCases{
+		"ccid64":  TestCaseCcId64Map,
+		"ccid96":  TestCaseCcId96Map,
+		"ccid128": TestCaseCcId128Map,
+		"ccid160": TestCaseCcId160Map,
+	}
+	for _, name := range SortKeys(groups) {
+		m := groups[name]
+		for _, key := range SortKeys(m) {
+			tc := m[key]
+			ctor, _ := ccIdCtorBySize(byte(len(tc.Bytes)))
+			id, _ := ctor(tc.timestamp, tc.Fingerprint, tc.payload)
+			bodies := map[byte]string{BASE62: tc.Base62, BASE32: tc.Base32, BASE16: tc.Base16}
+			for _, base := range []byte{BASE62, BASE32, BASE16} {
+				t.Run(fmt.Sprintf("%s_%s_base%d", name, key, base), func(t *testing.T) {
+					s, err := FormatSelfDescribing(id, base)
+					if err != nil {
+						t.Errorf("FormatSelfDescribing() error = %v", err)
+						return
+					}
+					if s[1:] != bodies[base] {
+						t.Errorf("FormatSelfDescribing() =\n%s, want\n_%s", s, bodies[base])
+					}
+					got, err := ParseSelfDescribing(s)
+					if err != nil {
+						t.Errorf("ParseSelfDescribing(%s) error = %v", s, err)
+						return
+					}
+					if v := fmt.Sprintf("%#v", got); v != tc.GoString {
+						t.Errorf("ParseSelfDescribing(%s) =\n%s, want\n%s", s, v, tc.GoString)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestSelfDescribing_UniqueHeaders(t *testing.T) {
+	seen := map[byte]string{}
+	for _, base := range []byte{BASE62, BASE32, BASE16} {
+		for _, size := range []byte{ByteSliceSize64, ByteSliceSize96, ByteSliceSize128, ByteSliceSize160} {
+			maxFp := MaxFingerprintSize
+			if size == ByteSliceSize64 {
+				maxFp = MaxFingerprintSize64
+			}
+			for fp := 0; fp <= maxFp; fp++ {
+				id := newCompareCcId(size, 1, make([]byte, fp), 0)
+				s, _ := FormatSelfDescribing(id, base)
+				layout := fmt.Sprintf("base%d size %d fp %d", base, size, fp)
+				if prev, ok := seen[s[0]]; ok {
+					t.Errorf("header %c used by %s and %s", s[0], prev, layout)
+				}
+				seen[s[0]] = layout
+			}
+		}
+	}
+	if len(seen) != 60 {
+		t.Errorf("headers = %d, want 60", len(seen))
+	}
+}
+
+func TestSelfDescribing_Error(t *testing.T) {
+	id := newCompareCcId(ByteSliceSize96, 1, []byte{1, 2}, 0)
+	var baseErr InvalidBaseError
+	if _, err := FormatSelfDescribing(id, 10); !errors.As(err, &baseErr) {
+		t.Errorf("FormatSelfDescribing() error = %v, want InvalidBaseError", err)
+	}
+	s, _ := FormatSelfDescribing(id, BASE62)
+	var headerErr InvalidHeaderError
+	var lengthErr InvalidLengthError
+	var charErr InvalidCharacterError
+	cases := map[string]struct {
+		s      string
+		target any
+	}{
+		"empty":          {"", &lengthErr},
+		"unknown header": {"z" + s[1:], &headerErr},
+		"invalid header": {"-" + s[1:], &headerErr},
+		"other size":     {s[:1] + "00000000000", &lengthErr},
+		"bad length":     {s + "0", &lengthErr},
+		"bad character":  {s[:5] + "*" + s[6:], &charErr},
+	}
+	for _, key := range SortKeys(cases) {
+		c := cases[key]
+		t.Run(key, func(t *testing.T) {
+			_, err := ParseSelfDescribing(c.s)
+			if !errors.As(err, c.target) {
+				t.Errorf("ParseSelfDescribing(%s) error = %v, want %T", c.s, err, c.target)
+			}
+		})
+	}
+	t.Run("character position", func(t *testing.T) {
+		_, err := ParseSelfDescribing(s[:5] + "*" + s[6:])
+		if errors.As(err, &charErr) && charErr.Pos != 5 {
+			t.Errorf("ParseSelfDescribing() error = %v, want position 5", err)
+		}
+	})
+}
+
+func TestSelfDescribing_Ms(t *testing.T) {
+	groups := map[string]map[string]CcIdMsTestCases{
+		"ccid96ms":  TestCaseCcId96MsMap,
+		"ccid128ms": TestCaseCcId128MsMap,
+		"ccid160ms": TestCaseCcId160MsMap,
+	}
+	seen := map[string]string{}
+	for _, name := range SortKeys(groups) {
+		m := groups[name]
+		for _, key := range SortKeys(m) {
+			tc := m[key]
+			ctor, _ := ccIdMsCtorBySize(byte(len(tc.Bytes)))
+			id, _ := ctor(tc.timestamp, tc.Fingerprint, tc.payload)
+			bodies := map[byte]string{BASE62: tc.Base62, BASE32: tc.Base32, BASE16: tc.Base16}
+			for _, base := range []byte{BASE62, BASE32, BASE16} {
+				t.Run(fmt.Sprintf("%s_%s_base%d", name, key, base), func(t *testing.T) {
+					s, err := FormatSelfDescribing(id, base)
+					if err != nil {
+						t.Errorf("FormatSelfDescribing() error = %v", err)
+						return
+					}
+					if s[0] != 'y' || s[2:] != bodies[base] {
+						t.Errorf("FormatSelfDescribing() =\n%s, want\ny_%s", s, bodies[base])
+					}
+					layout := fmt.Sprintf("%s base%d fp %d", name, base, len(tc.Fingerprint))
+					if prev, ok := seen[s[:2]]; ok && prev != layout {
+						t.Errorf("header %s used by %s and %s", s[:2], prev, layout)
+					}
+					seen[s[:2]] = layout
+					got, err := ParseSelfDescribing(s)
+					if err != nil {
+						t.Errorf("ParseSelfDescribing(%s) error = %v", s, err)
+						return
+					}
+					if v := fmt.Sprintf("%#v", got); v != tc.GoString {
+						t.Errorf("ParseSelfDescribing(%s) =\n%s, want\n%s", s, v, tc.GoString)
+					}
+				})
+			}
+		}
+	}
+	var headerErr InvalidHeaderError
+	if _, err := ParseSelfDescribing("yz0000"); !errors.As(err, &headerErr) {
+		t.Errorf("ParseSelfDescribing(yz0000) error = %v, want InvalidHeaderError", err)
+	}
+}
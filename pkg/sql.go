@@ -0,0 +1,242 @@
+package pkg
+
+import (
+	"database/sql/driver"
+)
+
+// Value implements driver.Valuer interface. CcId is stored as raw bytes.
+func (id CcId64) Value() (driver.Value, error) {
+	return id.data[:], nil
+}
+
+// Scan implements sql.Scanner interface.
+// It accepts raw bytes and base62, base32 or base16 text, fingerprint size of the receiver is preserved.
+func (id *CcId64) Scan(src any) error {
+	v, err := scanCcId(src, ccIdLayout{size: ByteSliceSize64}, id.fingerprintSize)
+	if err != nil {
+		return err
+	}
+	*id = v.(CcId64)
+	return nil
+}
+
+// Value implements driver.Valuer interface. CcId is stored as raw bytes.
+func (id CcId96) Value() (driver.Value, error) {
+	return id.data[:], nil
+}
+
+// Scan implements sql.Scanner interface.
+// It accepts raw bytes and base62, base32 or base16 text, fingerprint size of the receiver is preserved.
+func (id *CcId96) Scan(src any) error {
+	v, err := scanCcId(src, ccIdLayout{size: ByteSliceSize96}, id.fingerprintSize)
+	if err != nil {
+		return err
+	}
+	*id = v.(CcId96)
+	return nil
+}
+
+// Value implements driver.Valuer interface. CcId is stored as raw bytes.
+func (id CcId128) Value() (driver.Value, error) {
+	return id.data[:], nil
+}
+
+// Scan implements sql.Scanner interface.
+// It accepts raw bytes and base62, base32 or base16 text, fingerprint size of the receiver is preserved.
+func (id *CcId128) Scan(src any) error {
+	v, err := scanCcId(src, ccIdLayout{size: ByteSliceSize128}, id.fingerprintSize)
+	if err != nil {
+		return err
+	}
+	*id = v.(CcId128)
+	return nil
+}
+
+// Value implements driver.Valuer interface. CcId is stored as raw bytes.
+func (id CcId160) Value() (driver.Value, error) {
+	return id.data[:], nil
+}
+
+// Scan implements sql.Scanner interface.
+// It accepts raw bytes and base62, base32 or base16 text, fingerprint size of the receiver is preserved.
+func (id *CcId160) Scan(src any) error {
+	v, err := scanCcId(src, ccIdLayout{size: ByteSliceSize160}, id.fingerprintSize)
+	if err != nil {
+		return err
+	}
+	*id = v.(CcId160)
+	return nil
+}
+
+// Value implements driver.Valuer interface. CcId is stored as raw bytes.
+func (id CcId96Ms) Value() (driver.Value, error) {
+	return id.data[:], nil
+}
+
+// Scan implements sql.Scanner interface.
+// It accepts raw bytes and base62, base32 or base16 text, fingerprint size of the receiver is preserved.
+func (id *CcId96Ms) Scan(src any) error {
+	v, err := scanCcId(src, ccIdLayout{size: ByteSliceSize96, millisecond: true}, id.fingerprintSize)
+	if err != nil {
+		return err
+	}
+	*id = v.(CcId96Ms)
+	return nil
+}
+
+// Value implements driver.Valuer interface. CcId is stored as raw bytes.
+func (id CcId128Ms) Value() (driver.Value, error) {
+	return id.data[:], nil
+}
+
+// Scan implements sql.Scanner interface.
+// It accepts raw bytes and base62, base32 or base16 text, fingerprint size of the receiver is preserved.
+func (id *CcId128Ms) Scan(src any) error {
+	v, err := scanCcId(src, ccIdLayout{size: ByteSliceSize128, millisecond: true}, id.fingerprintSize)
+	if err != nil {
+		return err
+	}
+	*id = v.(CcId128Ms)
+	return nil
+}
+
+// Value implements driver.Valuer interface. CcId is stored as raw bytes.
+func (id CcId160Ms) Value() (driver.Value, error) {
+	return id.data[:], nil
+}
+
+// Scan implements sql.Scanner interface.
+// It accepts raw bytes and base62, base32 or base16 text, fingerprint size of the receiver is preserved.
+func (id *CcId160Ms) Scan(src any) error {
+	v, err := scanCcId(src, ccIdLayout{size: ByteSliceSize160, millisecond: true}, id.fingerprintSize)
+	if err != nil {
+		return err
+	}
+	*id = v.(CcId160Ms)
+	return nil
+}
+
+// SQLFormat describes how CcIds are stored in a database column.
+type SQLFormat struct {
+	// Size of the CcId in bytes. 0 - detected from the stored value.
+	Size byte
+	// Base of a text column, BASE62, BASE32 or BASE16. 0 - binary column with raw bytes.
+	Base byte
+	// FingerprintSize of the stored CcIds in bytes.
+	FingerprintSize byte
+	// Millisecond is true for CcId96Ms, CcId128Ms and CcId160Ms columns.
+	Millisecond bool
+}
+
+// Value converts CcId to the column value.
+func (f SQLFormat) Value(id CcId) (driver.Value, error) {
+	if f.Size != 0 && id.Size() != f.Size {
+		return nil, InvalidLengthError(id.Size())
+	}
+	if layoutOf(id).millisecond != f.Millisecond {
+		return nil, InvalidTimestampPrecisionError(f.Millisecond)
+	}
+	if f.Base == 0 {
+		return append([]byte(nil), id.Bytes()...), nil
+	}
+	return EncodeToBase(id.Bytes(), f.Base)
+}
+
+// Scan converts the column value to CcId.
+// 'src' must be []byte or string, NULL values are not supported, see NullSQLCcId.
+func (f SQLFormat) Scan(src any) (CcId, error) {
+	var b []byte
+	var err error
+	switch v := src.(type) {
+	case []byte:
+		if f.Base == 0 {
+			b = v
+			break
+		}
+		b, err = DecodeFromBase(string(v), f.Base)
+	case string:
+		if f.Base == 0 {
+			return nil, InvalidScanTypeError{src}
+		}
+		b, err = DecodeFromBase(v, f.Base)
+	default:
+		return nil, InvalidScanTypeError{src}
+	}
+	if err != nil {
+		return nil, err
+	}
+	size := byte(len(b))
+	if f.Size != 0 && size != f.Size {
+		return nil, InvalidLengthError(size)
+	}
+	return ccIdLayout{size: size, millisecond: f.Millisecond}.fromBytes(b, f.FingerprintSize)
+}
+
+// SQLCcId implements sql.Scanner and driver.Valuer interfaces for a column with the given format.
+type SQLCcId struct {
+	Id     CcId
+	Format SQLFormat
+}
+
+// Scan implements sql.Scanner interface.
+func (v *SQLCcId) Scan(src any) error {
+	id, err := v.Format.Scan(src)
+	if err != nil {
+		return err
+	}
+	v.Id = id
+	return nil
+}
+
+// Value implements driver.Valuer interface.
+func (v SQLCcId) Value() (driver.Value, error) {
+	if v.Id == nil {
+		return nil, InvalidLengthError(0)
+	}
+	return v.Format.Value(v.Id)
+}
+
+// NullSQLCcId implements sql.Scanner and driver.Valuer interfaces for a nullable column with the given format.
+// Nil CcIds of every type and nil are stored as NULL.
+type NullSQLCcId struct {
+	Id     CcId
+	Valid  bool // Valid is true if Id is not NULL
+	Format SQLFormat
+}
+
+// Scan implements sql.Scanner interface.
+// NULL value sets Valid to false and Id to nil CcId of the format size.
+func (v *NullSQLCcId) Scan(src any) error {
+	if src == nil {
+		v.Id, _ = ccIdLayout{size: v.Format.Size, millisecond: v.Format.Millisecond}.nilCcId()
+		v.Valid = false
+		return nil
+	}
+	id, err := v.Format.Scan(src)
+	if err != nil {
+		return err
+	}
+	v.Id, v.Valid = id, true
+	return nil
+}
+
+// Value implements driver.Valuer interface.
+func (v NullSQLCcId) Value() (driver.Value, error) {
+	if !v.Valid || v.Id == nil || isNilCcId(v.Id) {
+		return nil, nil
+	}
+	return v.Format.Value(v.Id)
+}
+
+func scanCcId(src any, layout ccIdLayout, fingerprintSize byte) (CcId, error) {
+	switch v := src.(type) {
+	case []byte:
+		if len(v) == int(layout.size) {
+			return layout.fromBytes(v, fingerprintSize)
+		}
+		return decodeCcIdString(string(v), layout, fingerprintSize)
+	case string:
+		return decodeCcIdString(v, layout, fingerprintSize)
+	}
+	return nil, InvalidScanTypeError{src}
+}
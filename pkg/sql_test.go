@@ -0,0 +1,176 @@
+package pkg
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCcId_ValueScan(t *testing.T) {
+	groups := map[string]map[string]CcIdTestCases{
+		"ccid64":  TestCaseCcId64Map,
+		"ccid96":  TestCaseCcId96Map,
+		"ccid128": TestCaseCcId128Map,
+		"ccid160": TestCaseCcId160Map,
+	}
+	for _, name := range SortKeys(groups) {
+		m := groups[name]
+		for _, key := range SortKeys(m) {
+			tc := m[key]
+			ctor, _ := ccIdCtorBySize(byte(len(tc.Bytes)))
+			id, _ := ctor(tc.timestamp, tc.Fingerprint, tc.payload)
+			t.Run(name+"_"+key+"_value", func(t *testing.T) {
+				v, err := id.(driver.Valuer).Value()
+				if err != nil {
+					t.Errorf("Value() error = %v", err)
+					return
+				}
+				if !SliceEqual(v.([]byte), tc.Bytes) {
+					t.Errorf("Value() =\n%x, want\n%x", v, tc.Bytes)
+				}
+			})
+			srcList := []any{tc.Bytes, tc.Base62, tc.Base32, []byte(tc.Base16)}
+			for idx, src := range srcList {
+				t.Run(fmt.Sprintf("%s_%s_scan_%d", name, key, idx), func(t *testing.T) {
+					layout, _ := ctor(0, make([]byte, len(tc.Fingerprint)), make([]byte, ByteSliceSize160))
+					scanner := newScanner(layout)
+					err := scanner.Scan(src)
+					if err != nil {
+						t.Errorf("Scan(%v) error = %v", src, err)
+						return
+					}
+					if v := fmt.Sprintf("%#v", scanner); v != tc.GoString {
+						t.Errorf("Scan(%v) =\n%s, want\n%s", src, v, tc.GoString)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestCcId_Scan_Error(t *testing.T) {
+	var id CcId96
+	var scanErr InvalidScanTypeError
+	if err := id.Scan(nil); !errors.As(err, &scanErr) {
+		t.Errorf("Scan(nil) error = %v, want InvalidScanTypeError", err)
+	}
+	if err := id.Scan(int64(12)); !errors.As(err, &scanErr) {
+		t.Errorf("Scan(12) error = %v, want InvalidScanTypeError", err)
+	}
+	var lengthErr InvalidLengthError
+	if err := id.Scan(make([]byte, ByteSliceSize64)); !errors.As(err, &lengthErr) {
+		t.Errorf("Scan(8 bytes) error = %v, want InvalidLengthError", err)
+	}
+	var charErr InvalidCharacterError
+	if err := id.Scan("0000000000*000000"); !errors.As(err, &charErr) || charErr.Pos != 10 {
+		t.Errorf("Scan() error = %v, want InvalidCharacterError at 10", err)
+	}
+}
+
+func TestSQLFormat(t *testing.T) {
+	tc := TestCaseCcId128Map["max fingerprint"]
+	id, _ := NewCcId128WithFingerprint(tc.timestamp, tc.Fingerprint, tc.payload)
+	fpSize := byte(len(tc.Fingerprint))
+	cases := map[string]struct {
+		format SQLFormat
+		stored driver.Value
+	}{
+		"binary":         {SQLFormat{FingerprintSize: fpSize}, tc.Bytes},
+		"binary sized":   {SQLFormat{Size: ByteSliceSize128, FingerprintSize: fpSize}, tc.Bytes},
+		"text base62":    {SQLFormat{Base: BASE62, FingerprintSize: fpSize}, tc.Base62},
+		"text base32":    {SQLFormat{Base: BASE32, FingerprintSize: fpSize}, tc.Base32},
+		"text base16":    {SQLFormat{Size: ByteSliceSize128, Base: BASE16, FingerprintSize: fpSize}, tc.Base16},
+		"base16 no size": {SQLFormat{Base: BASE16, FingerprintSize: fpSize}, tc.Base16},
+	}
+	for _, key := range SortKeys(cases) {
+		c := cases[key]
+		t.Run(key, func(t *testing.T) {
+			v, err := SQLCcId{Id: id, Format: c.format}.Value()
+			if err != nil || fmt.Sprintf("%x", v) != fmt.Sprintf("%x", c.stored) {
+				t.Errorf("Value() =\n%v, %v, want\n%v", v, err, c.stored)
+			}
+			scanned := SQLCcId{Format: c.format}
+			err = scanned.Scan(c.stored)
+			if err != nil {
+				t.Errorf("Scan(%v) error = %v", c.stored, err)
+				return
+			}
+			if fmt.Sprintf("%#v", scanned.Id) != tc.GoString {
+				t.Errorf("Scan(%v) =\n%#v, want\n%s", c.stored, scanned.Id, tc.GoString)
+			}
+		})
+	}
+	t.Run("size mismatch", func(t *testing.T) {
+		var lengthErr InvalidLengthError
+		f := SQLFormat{Size: ByteSliceSize96}
+		if _, err := f.Scan(tc.Bytes); !errors.As(err, &lengthErr) {
+			t.Errorf("Scan() error = %v, want InvalidLengthError", err)
+		}
+		if _, err := f.Value(id); !errors.As(err, &lengthErr) {
+			t.Errorf("Value() error = %v, want InvalidLengthError", err)
+		}
+	})
+	t.Run("text into binary", func(t *testing.T) {
+		var scanErr InvalidScanTypeError
+		if _, err := (SQLFormat{}).Scan(tc.Base62); !errors.As(err, &scanErr) {
+			t.Errorf("Scan() error = %v, want InvalidScanTypeError", err)
+		}
+	})
+}
+
+func TestNullSQLCcId(t *testing.T) {
+	tc := TestCaseCcId64Map["some id fingerprint"]
+	id, _ := NewCcId64WithFingerprint(tc.timestamp, tc.Fingerprint, tc.payload)
+	f := SQLFormat{Size: ByteSliceSize64, Base: BASE32, FingerprintSize: 1}
+	var _ sql.Scanner = &NullSQLCcId{}
+
+	nullCases := map[string]NullSQLCcId{
+		"invalid":  {Id: id, Format: f},
+		"nil":      {Valid: true, Format: f},
+		"nil ccid": {Id: NilCcId64, Valid: true, Format: f},
+	}
+	for _, key := range SortKeys(nullCases) {
+		t.Run(key, func(t *testing.T) {
+			v, err := nullCases[key].Value()
+			if v != nil || err != nil {
+				t.Errorf("Value() = %v, %v, want nil", v, err)
+			}
+		})
+	}
+	t.Run("value", func(t *testing.T) {
+		v, err := NullSQLCcId{Id: id, Valid: true, Format: f}.Value()
+		if v != tc.Base32 || err != nil {
+			t.Errorf("Value() = %v, %v, want %s", v, err, tc.Base32)
+		}
+	})
+	t.Run("scan null", func(t *testing.T) {
+		v := NullSQLCcId{Id: id, Valid: true, Format: f}
+		err := v.Scan(nil)
+		if err != nil || v.Valid || v.Id != NilCcId64 {
+			t.Errorf("Scan(nil) = %#v, %v, want NilCcId64", v, err)
+		}
+	})
+	t.Run("scan", func(t *testing.T) {
+		v := NullSQLCcId{Format: f}
+		err := v.Scan([]byte(tc.Base32))
+		if err != nil || !v.Valid || fmt.Sprintf("%#v", v.Id) != tc.GoString {
+			t.Errorf("Scan(%s) = %#v, %v, want\n%s", tc.Base32, v.Id, err, tc.GoString)
+		}
+	})
+}
+
+func newScanner(v CcId) sql.Scanner {
+	switch id := v.(type) {
+	case CcId64:
+		return &id
+	case CcId96:
+		return &id
+	case CcId128:
+		return &id
+	case CcId160:
+		return &id
+	}
+	return nil
+}
@@ -884,6 +884,160 @@ var TestCaseCcId160Map = map[string]CcIdTestCases{
 	},
 }
 
+type CcIdMsTestCases struct {
+	name        string
+	timestamp   uint64
+	time        time.Time
+	Fingerprint []byte
+	payload     []byte
+	Bytes       []byte
+	Base62      string
+	Base32      string
+	Base16      string
+	GoString    string
+}
+
+var TestCaseCcId96MsMap = map[string]CcIdMsTestCases{
+	"min id": {
+		timestamp:   0,
+		time:        time.Date(2014, 5, 13, 16, 53, 20, 0, time.UTC),
+		Fingerprint: nil,
+		payload:     []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		Bytes:       []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		Base62:      "00000000000000000",
+		Base32:      "00000000000000000000",
+		Base16:      "000000000000000000000000",
+		GoString:    "CcIdMs{size: 12, timestamp: 0 (2014-05-13T16:53:20.000Z), payload: 0x000000000000}",
+	},
+	"some id": {
+		timestamp:   0x123456789ab,
+		time:        time.Date(2054, 1, 2, 20, 51, 36, 491000000, time.UTC),
+		Fingerprint: nil,
+		payload:     []byte{0x11, 0x22, 0x33, 0x44, 0x55, 0x66},
+		Bytes:       []byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66},
+		Base62:      "00SONE5v6sSH4vB3e",
+		Base32:      "00938NKRKARH48SM8NB6",
+		Base16:      "0123456789AB112233445566",
+		GoString:    "CcIdMs{size: 12, timestamp: 1250999896491 (2054-01-02T20:51:36.491Z), payload: 0x112233445566}",
+	},
+	"fingerprint": {
+		timestamp:   0x123456789ab,
+		time:        time.Date(2054, 1, 2, 20, 51, 36, 491000000, time.UTC),
+		Fingerprint: []byte{0xdd, 0xee, 0xff},
+		payload:     []byte{0x11, 0x22, 0x33},
+		Bytes:       []byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xdd, 0xee, 0xff, 0x11, 0x22, 0x33},
+		Base62:      "00SONE5v7uOh5gWCh",
+		Base32:      "00938NKRKAYXXVZH28HK",
+		Base16:      "0123456789ABDDEEFF112233",
+		GoString:    "CcIdMs{size: 12, timestamp: 1250999896491 (2054-01-02T20:51:36.491Z), fingerprint: 0xddeeff, payload: 0x112233}",
+	},
+	"max id": {
+		timestamp:   MaxTimestampMs,
+		time:        time.Date(10933, 12, 13, 22, 25, 10, 655000000, time.UTC),
+		Fingerprint: nil,
+		payload:     []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		Bytes:       []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		Base62:      "1f2SI9UJPXvb7vdJ1",
+		Base32:      "1ZZZZZZZZZZZZZZZZZZZ",
+		Base16:      "FFFFFFFFFFFFFFFFFFFFFFFF",
+		GoString:    "CcIdMs{size: 12, timestamp: 281474976710655 (10933-12-13T22:25:10.655Z), payload: 0xffffffffffff}",
+	},
+}
+
+var TestCaseCcId128MsMap = map[string]CcIdMsTestCases{
+	"min id": {
+		timestamp:   0,
+		time:        time.Date(2014, 5, 13, 16, 53, 20, 0, time.UTC),
+		Fingerprint: nil,
+		payload:     []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		Bytes:       []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		Base62:      "0000000000000000000000",
+		Base32:      "00000000000000000000000000",
+		Base16:      "00000000000000000000000000000000",
+		GoString:    "CcIdMs{size: 16, timestamp: 0 (2014-05-13T16:53:20.000Z), payload: 0x00000000000000000000}",
+	},
+	"some id": {
+		timestamp:   0x123456789ab,
+		time:        time.Date(2054, 1, 2, 20, 51, 36, 491000000, time.UTC),
+		Fingerprint: nil,
+		payload:     []byte{0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa},
+		Bytes:       []byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa},
+		Base62:      "0296tiiBWb8Mm8orHksimA",
+		Base32:      "014D2PF2DB24H36H2NCSVRH6DA",
+		Base16:      "0123456789AB112233445566778899AA",
+		GoString:    "CcIdMs{size: 16, timestamp: 1250999896491 (2054-01-02T20:51:36.491Z), payload: 0x112233445566778899aa}",
+	},
+	"fingerprint": {
+		timestamp:   0x123456789ab,
+		time:        time.Date(2054, 1, 2, 20, 51, 36, 491000000, time.UTC),
+		Fingerprint: []byte{0xdd, 0xee, 0xff},
+		payload:     []byte{0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77},
+		Bytes:       []byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xdd, 0xee, 0xff, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77},
+		Base62:      "0296tiiBbQu9kUU3rA4JV9",
+		Base32:      "014D2PF2DBVQQFY4926D25ASKQ",
+		Base16:      "0123456789ABDDEEFF11223344556677",
+		GoString:    "CcIdMs{size: 16, timestamp: 1250999896491 (2054-01-02T20:51:36.491Z), fingerprint: 0xddeeff, payload: 0x11223344556677}",
+	},
+	"max id": {
+		timestamp:   MaxTimestampMs,
+		time:        time.Date(10933, 12, 13, 22, 25, 10, 655000000, time.UTC),
+		Fingerprint: nil,
+		payload:     []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		Bytes:       []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		Base62:      "7n42DGM5Tflk9n8mt7Fhc7",
+		Base32:      "7ZZZZZZZZZZZZZZZZZZZZZZZZZ",
+		Base16:      "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF",
+		GoString:    "CcIdMs{size: 16, timestamp: 281474976710655 (10933-12-13T22:25:10.655Z), payload: 0xffffffffffffffffffff}",
+	},
+}
+
+var TestCaseCcId160MsMap = map[string]CcIdMsTestCases{
+	"min id": {
+		timestamp:   0,
+		time:        time.Date(2014, 5, 13, 16, 53, 20, 0, time.UTC),
+		Fingerprint: nil,
+		payload:     []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		Bytes:       []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		Base62:      "000000000000000000000000000",
+		Base32:      "00000000000000000000000000000000",
+		Base16:      "0000000000000000000000000000000000000000",
+		GoString:    "CcIdMs{size: 20, timestamp: 0 (2014-05-13T16:53:20.000Z), payload: 0x0000000000000000000000000000}",
+	},
+	"some id": {
+		timestamp:   0x123456789ab,
+		time:        time.Date(2054, 1, 2, 20, 51, 36, 491000000, time.UTC),
+		Fingerprint: nil,
+		payload:     []byte{0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee},
+		Bytes:       []byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee},
+		Base62:      "0A42ooYeB2Tf6YcJnPKqqOVgv5q",
+		Base32:      "04HMASW9NC8J4CT4ANK7F24SNAXWSQFE",
+		Base16:      "0123456789AB112233445566778899AABBCCDDEE",
+		GoString:    "CcIdMs{size: 20, timestamp: 1250999896491 (2054-01-02T20:51:36.491Z), payload: 0x112233445566778899aabbccddee}",
+	},
+	"fingerprint": {
+		timestamp:   0x123456789ab,
+		time:        time.Date(2054, 1, 2, 20, 51, 36, 491000000, time.UTC),
+		Fingerprint: []byte{0xdd, 0xee, 0xff},
+		payload:     []byte{0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb},
+		Bytes:       []byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xdd, 0xee, 0xff, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb},
+		Base62:      "0A42ooYeXhqcF7IWvNyO32OdVMZ",
+		Base32:      "04HMASW9NFEYXZRH48SM8NB6EY49KANV",
+		Base16:      "0123456789ABDDEEFF112233445566778899AABB",
+		GoString:    "CcIdMs{size: 20, timestamp: 1250999896491 (2054-01-02T20:51:36.491Z), fingerprint: 0xddeeff, payload: 0x112233445566778899aabb}",
+	},
+	"max id": {
+		timestamp:   MaxTimestampMs,
+		time:        time.Date(10933, 12, 13, 22, 25, 10, 655000000, time.UTC),
+		Fingerprint: nil,
+		payload:     []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		Bytes:       []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		Base62:      "aWgEPTl1tmebfsQzFP4bxwgy80V",
+		Base32:      "ZZZZZZZZZZZZZZZZZZZZZZZZZZZZZZZZ",
+		Base16:      "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF",
+		GoString:    "CcIdMs{size: 20, timestamp: 281474976710655 (10933-12-13T22:25:10.655Z), payload: 0xffffffffffffffffffffffffffff}",
+	},
+}
+
 func SortKeys[T any](m map[string]T) []string {
 	keys := make([]string, len(m))
 	i := 0
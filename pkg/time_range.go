@@ -0,0 +1,103 @@
+package pkg
+
+import (
+	"math"
+	"time"
+)
+
+// MinCcIdForTime returns the smallest CcId of 'size' bytes with timestamp of 't', payload is filled with 0x00.
+// With 'fingerprint' it's the smallest CcId of the fingerprint, otherwise fingerprint bytes are part of the payload.
+// It returns TimestampOutOfRangeError if 't' is out of timestamp range instead of wrapping like ToAdjustedTimestamp.
+func MinCcIdForTime(t time.Time, size byte, fingerprint []byte) (CcId, error) {
+	return ccIdForTime(t, size, fingerprint, 0x00)
+}
+
+// MaxCcIdForTime returns the largest CcId of 'size' bytes with timestamp of 't', payload is filled with 0xFF.
+// See MinCcIdForTime.
+func MaxCcIdForTime(t time.Time, size byte, fingerprint []byte) (CcId, error) {
+	return ccIdForTime(t, size, fingerprint, 0xFF)
+}
+
+func ccIdForTime(t time.Time, size byte, fingerprint []byte, fill byte) (CcId, error) {
+	ctor, err := ccIdCtorBySize(size)
+	if err != nil {
+		return nil, err
+	}
+	timestamp, err := AdjustTimestamp(t, DefaultEpoch, TimestampRangeError)
+	if err != nil {
+		return nil, err
+	}
+	// constructor takes as much payload as fits after the fingerprint
+	var payload [ByteSliceSize160]byte
+	for i := range payload {
+		payload[i] = fill
+	}
+	return ctor(timestamp, fingerprint, payload[:size])
+}
+
+// CcIdBound is a bound of CcIdRange in every encoding.
+// Encoded strings have fixed length and sorted alphabets, so their lexicographic order matches order of the bytes.
+type CcIdBound struct {
+	Bytes  []byte
+	Base62 string
+	Base32 string
+	Base16 string
+}
+
+func newCcIdBound(id CcId) CcIdBound {
+	return CcIdBound{
+		Bytes:  append([]byte(nil), id.Bytes()...),
+		Base62: id.AsBase62(),
+		Base32: id.AsBase32(),
+		Base16: id.AsBase16(),
+	}
+}
+
+// CcIdRange is a half-open range of CcIds, From <= id < To, e.g. for `WHERE id >= $from AND id < $to`.
+// It is inclusive at the end of timestamp range, see TimeRange.
+type CcIdRange struct {
+	From CcIdBound
+	To   CcIdBound
+}
+
+// TimeRange returns CcIdRange of 'size' bytes covering every second overlapping [from, to).
+// Timestamps have second precision, so 'from' is truncated and 'to' is rounded up to a second.
+// The range is empty, From equals To, if 'to' isn't after 'from'.
+// With 'fingerprint' the bounds are CcIds of the fingerprint, see MinCcIdForTime,
+// CcIds of other fingerprints are still in the range except the first and the last second.
+// If 'to' is inside the last representable second, the exclusive bound would be out of timestamp range,
+// so To is MaxCcIdForTime of that second and the range is inclusive there, From <= id <= To.
+// It returns TimestampOutOfRangeError if the bounds are out of timestamp range.
+func TimeRange(from, to time.Time, size byte, fingerprint []byte) (CcIdRange, error) {
+	lower, err := MinCcIdForTime(from, size, fingerprint)
+	if err != nil {
+		return CcIdRange{}, err
+	}
+	bound := newCcIdBound(lower)
+	if !to.After(from) {
+		return CcIdRange{From: bound, To: bound}, nil
+	}
+	end := to.Truncate(time.Second)
+	if end.Before(to) {
+		end = end.Add(time.Second)
+	}
+	if end.Unix()-epochStamp > math.MaxUint32+1 {
+		return CcIdRange{}, TimestampOutOfRangeError{
+			Time: to,
+			Min:  ToStandardizedTime(0),
+			Max:  ToStandardizedTime(math.MaxUint32),
+		}
+	}
+	if end.Unix()-epochStamp > math.MaxUint32 {
+		upper, err := MaxCcIdForTime(end.Add(-time.Second), size, fingerprint)
+		if err != nil {
+			return CcIdRange{}, err
+		}
+		return CcIdRange{From: bound, To: newCcIdBound(upper)}, nil
+	}
+	upper, err := MinCcIdForTime(end, size, fingerprint)
+	if err != nil {
+		return CcIdRange{}, err
+	}
+	return CcIdRange{From: bound, To: newCcIdBound(upper)}, nil
+}
@@ -0,0 +1,160 @@
+package pkg
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMinMaxCcIdForTime(t *testing.T) {
+	tm := time.Date(2024, 1, 16, 15, 44, 56, 700_000_000, time.UTC)
+	ts := ToAdjustedTimestamp(tm)
+	cases := map[string]struct {
+		size        byte
+		fingerprint []byte
+		min         []byte
+		max         []byte
+	}{
+		"64":                  {ByteSliceSize64, nil, []byte{0, 0, 0, 0}, []byte{0xFF, 0xFF, 0xFF, 0xFF}},
+		"64 fingerprint":      {ByteSliceSize64, []byte{0xAB}, []byte{0xAB, 0, 0, 0}, []byte{0xAB, 0xFF, 0xFF, 0xFF}},
+		"96":                  {ByteSliceSize96, nil, make([]byte, 8), bytes.Repeat([]byte{0xFF}, 8)},
+		"128 fingerprint":     {ByteSliceSize128, []byte{1, 2}, append([]byte{1, 2}, make([]byte, 10)...), append([]byte{1, 2}, bytes.Repeat([]byte{0xFF}, 10)...)},
+		"160 max fingerprint": {ByteSliceSize160, []byte{1, 2, 3, 4, 5}, append([]byte{1, 2, 3, 4, 5}, make([]byte, 11)...), append([]byte{1, 2, 3, 4, 5}, bytes.Repeat([]byte{0xFF}, 11)...)},
+	}
+	for _, key := range SortKeys(cases) {
+		tc := cases[key]
+		t.Run(key, func(t *testing.T) {
+			minId, err := MinCcIdForTime(tm, tc.size, tc.fingerprint)
+			if err != nil || minId.Timestamp() != ts || !SliceEqual(minId.Bytes()[TimestampSize:], tc.min) {
+				t.Errorf("MinCcIdForTime() = %x, %v, want timestamp %d and %x", minId.Bytes(), err, ts, tc.min)
+			}
+			maxId, err := MaxCcIdForTime(tm, tc.size, tc.fingerprint)
+			if err != nil || maxId.Timestamp() != ts || !SliceEqual(maxId.Bytes()[TimestampSize:], tc.max) {
+				t.Errorf("MaxCcIdForTime() = %x, %v, want timestamp %d and %x", maxId.Bytes(), err, ts, tc.max)
+			}
+			if !SliceEqual(minId.Fingerprint(), tc.fingerprint) || !SliceEqual(maxId.Fingerprint(), tc.fingerprint) {
+				t.Errorf("Fingerprint() = %x, %x, want %x", minId.Fingerprint(), maxId.Fingerprint(), tc.fingerprint)
+			}
+		})
+	}
+}
+
+func TestMinMaxCcIdForTime_Error(t *testing.T) {
+	tm := time.Date(2024, 1, 16, 15, 44, 56, 0, time.UTC)
+	cases := map[string]struct {
+		t           time.Time
+		size        byte
+		fingerprint []byte
+		target      error
+	}{
+		"size":         {tm, 15, nil, InvalidLengthError(15)},
+		"fingerprint":  {tm, ByteSliceSize64, []byte{1, 2}, InvalidFingerprintSizeError{ProvidedSize: 2, RequiredSize: MaxFingerprintSize64}},
+		"before epoch": {DefaultEpoch.Add(-time.Second), ByteSliceSize96, nil, TimestampOutOfRangeError{}},
+	}
+	for _, key := range SortKeys(cases) {
+		tc := cases[key]
+		t.Run(key, func(t *testing.T) {
+			_, errMin := MinCcIdForTime(tc.t, tc.size, tc.fingerprint)
+			_, errMax := MaxCcIdForTime(tc.t, tc.size, tc.fingerprint)
+			for _, err := range []error{errMin, errMax} {
+				if _, ok := tc.target.(TimestampOutOfRangeError); ok {
+					if !errors.As(err, &TimestampOutOfRangeError{}) {
+						t.Errorf("error = %v, want TimestampOutOfRangeError", err)
+					}
+				} else if !errors.Is(err, tc.target) {
+					t.Errorf("error = %v, want %v", err, tc.target)
+				}
+			}
+		})
+	}
+}
+
+func TestTimeRange(t *testing.T) {
+	start := time.Date(2024, 1, 16, 15, 44, 56, 0, time.UTC)
+	cases := map[string]struct {
+		from     time.Time
+		to       time.Time
+		wantFrom time.Time
+		wantTo   time.Time
+	}{
+		"seconds":     {start, start.Add(time.Minute), start, start.Add(time.Minute)},
+		"fractions":   {start.Add(300 * time.Millisecond), start.Add(1700 * time.Millisecond), start, start.Add(2 * time.Second)},
+		"same second": {start.Add(300 * time.Millisecond), start.Add(400 * time.Millisecond), start, start.Add(time.Second)},
+		"empty":       {start, start, start, start},
+		"reversed":    {start.Add(time.Second), start, start.Add(time.Second), start.Add(time.Second)},
+	}
+	for _, key := range SortKeys(cases) {
+		tc := cases[key]
+		t.Run(key, func(t *testing.T) {
+			r, err := TimeRange(tc.from, tc.to, ByteSliceSize128, []byte{0x55})
+			if err != nil {
+				t.Fatalf("TimeRange() error = %v", err)
+			}
+			from, _ := MinCcIdForTime(tc.wantFrom, ByteSliceSize128, []byte{0x55})
+			to, _ := MinCcIdForTime(tc.wantTo, ByteSliceSize128, []byte{0x55})
+			if !SliceEqual(r.From.Bytes, from.Bytes()) || r.From.Base62 != from.AsBase62() ||
+				r.From.Base32 != from.AsBase32() || r.From.Base16 != from.AsBase16() {
+				t.Errorf("TimeRange().From = %+v, want %x", r.From, from.Bytes())
+			}
+			if !SliceEqual(r.To.Bytes, to.Bytes()) || r.To.Base62 != to.AsBase62() ||
+				r.To.Base32 != to.AsBase32() || r.To.Base16 != to.AsBase16() {
+				t.Errorf("TimeRange().To = %+v, want %x", r.To, to.Bytes())
+			}
+		})
+	}
+}
+
+func TestTimeRange_Order(t *testing.T) {
+	start := time.Date(2024, 1, 16, 15, 44, 56, 0, time.UTC)
+	for _, size := range []byte{ByteSliceSize64, ByteSliceSize96, ByteSliceSize128, ByteSliceSize160} {
+		r, _ := TimeRange(start, start.Add(time.Second), size, nil)
+		inside, _ := MaxCcIdForTime(start, size, nil)
+		before, _ := MaxCcIdForTime(start.Add(-time.Second), size, nil)
+		ids := []CcIdBound{newCcIdBound(before), r.From, newCcIdBound(inside), r.To}
+		for i := 1; i < len(ids); i++ {
+			a, b := ids[i-1], ids[i]
+			if bytes.Compare(a.Bytes, b.Bytes) >= 0 || a.Base62 >= b.Base62 || a.Base32 >= b.Base32 || a.Base16 >= b.Base16 {
+				t.Errorf("size %d: %+v is not before %+v", size, a, b)
+			}
+		}
+	}
+}
+
+func TestTimeRange_LastSecond(t *testing.T) {
+	last := ToStandardizedTime(0xFFFFFFFF)
+	cases := map[string]struct {
+		to          time.Time
+		fingerprint []byte
+		want        string
+	}{
+		"inside":      {last.Add(500 * time.Millisecond), nil, "FFFFFFFFFFFFFFFFFFFFFFFF"},
+		"end":         {last.Add(time.Second), nil, "FFFFFFFFFFFFFFFFFFFFFFFF"},
+		"fingerprint": {last.Add(time.Millisecond), []byte{0xAA, 0xBB}, "FFFFFFFFAABBFFFFFFFFFFFF"},
+	}
+	for _, key := range SortKeys(cases) {
+		c := cases[key]
+		t.Run(key, func(t *testing.T) {
+			r, err := TimeRange(last, c.to, ByteSliceSize96, c.fingerprint)
+			if err != nil || r.To.Base16 != c.want {
+				t.Errorf("TimeRange(%s) = %+v, %v, want To %s", c.to, r.To, err, c.want)
+			}
+		})
+	}
+}
+
+func TestTimeRange_Error(t *testing.T) {
+	last := ToStandardizedTime(0xFFFFFFFF)
+	_, err := TimeRange(last, last.Add(1500*time.Millisecond), ByteSliceSize96, nil)
+	if !errors.As(err, &TimestampOutOfRangeError{}) {
+		t.Errorf("TimeRange() error = %v, want TimestampOutOfRangeError", err)
+	}
+	r, err := TimeRange(last.Add(-time.Second), last, ByteSliceSize96, nil)
+	if err != nil || r.To.Base16 != "FFFFFFFF0000000000000000" {
+		t.Errorf("TimeRange() = %+v, %v, want To FFFFFFFF0000000000000000", r.To, err)
+	}
+	_, err = TimeRange(DefaultEpoch.Add(-time.Second), DefaultEpoch, ByteSliceSize96, nil)
+	if !errors.As(err, &TimestampOutOfRangeError{}) {
+		t.Errorf("TimeRange() error = %v, want TimestampOutOfRangeError", err)
+	}
+}
@@ -1,8 +1,31 @@
 package pkg
 
-import "time"
+import (
+	"math"
+	"time"
+)
+
+// TimestampRangePolicy defines how time out of timestamp range is converted to a timestamp.
+type TimestampRangePolicy byte
+
+const (
+	// TimestampRangeError returns TimestampOutOfRangeError, it's the default policy.
+	TimestampRangeError TimestampRangePolicy = iota
+	// TimestampRangeClamp uses the min timestamp for earlier times and the max timestamp for later times.
+	TimestampRangeClamp
+	// TimestampRangeWrap wraps timestamp around to the start of the range, next era begins with timestamp 0.
+	TimestampRangeWrap
+)
+
+var (
+	// DefaultEpoch is the epoch of CcId timestamps, 2014-05-13T16:53:20Z.
+	DefaultEpoch = time.Unix(epochStamp, 0).UTC()
+	// UnixEpoch is 1970-01-01T00:00:00Z, it's useful to interoperate with systems using Unix timestamps.
+	UnixEpoch = time.Unix(0, 0).UTC()
+)
 
 // ToAdjustedTimestamp converts a time.Time to a uint32 timestamp with custom epoch
+// Time out of timestamp range silently wraps, use AdjustTimestamp to validate it.
 func ToAdjustedTimestamp(t time.Time) uint32 {
 	return uint32(t.Unix() - epochStamp)
 }
@@ -11,3 +34,89 @@ func ToAdjustedTimestamp(t time.Time) uint32 {
 func ToStandardizedTime(ts uint32) time.Time {
 	return time.Unix(int64(ts)+epochStamp, 0).UTC()
 }
+
+// ToAdjustedTimestampMs converts a time.Time to a millisecond timestamp with custom epoch
+func ToAdjustedTimestampMs(t time.Time) uint64 {
+	return uint64(t.UnixMilli()-epochStamp*1000) & MaxTimestampMs
+}
+
+// ToStandardizedTimeMs converts a millisecond timestamp with custom epoch to a UTC time.Time
+func ToStandardizedTimeMs(ts uint64) time.Time {
+	return time.UnixMilli(int64(ts) + epochStamp*1000).UTC()
+}
+
+// ToAdjustedTimestampWithEpoch converts a time.Time to a uint32 timestamp counted from 'epoch'.
+// Zero 'epoch' means DefaultEpoch, sub-second part of 'epoch' is truncated.
+func ToAdjustedTimestampWithEpoch(t time.Time, epoch time.Time) uint32 {
+	return uint32(t.Unix() - epochSeconds(epoch))
+}
+
+// ToStandardizedTimeWithEpoch converts a uint32 timestamp counted from 'epoch' to a UTC time.Time.
+// Zero 'epoch' means DefaultEpoch, sub-second part of 'epoch' is truncated.
+func ToStandardizedTimeWithEpoch(ts uint32, epoch time.Time) time.Time {
+	return time.Unix(int64(ts)+epochSeconds(epoch), 0).UTC()
+}
+
+// ToAdjustedTimestampMsWithEpoch converts a time.Time to a millisecond timestamp counted from 'epoch'.
+// Zero 'epoch' means DefaultEpoch, sub-second part of 'epoch' is truncated.
+func ToAdjustedTimestampMsWithEpoch(t time.Time, epoch time.Time) uint64 {
+	return uint64(t.UnixMilli()-epochSeconds(epoch)*1000) & MaxTimestampMs
+}
+
+// ToStandardizedTimeMsWithEpoch converts a millisecond timestamp counted from 'epoch' to a UTC time.Time.
+// Zero 'epoch' means DefaultEpoch, sub-second part of 'epoch' is truncated.
+func ToStandardizedTimeMsWithEpoch(ts uint64, epoch time.Time) time.Time {
+	return time.UnixMilli(int64(ts) + epochSeconds(epoch)*1000).UTC()
+}
+
+// AdjustTimestamp converts a time.Time to a uint32 timestamp counted from 'epoch' applying 'policy'
+// to time out of timestamp range. Zero 'epoch' means DefaultEpoch.
+func AdjustTimestamp(t time.Time, epoch time.Time, policy TimestampRangePolicy) (uint32, error) {
+	ts, ok := applyRangePolicy(t.Unix()-epochSeconds(epoch), math.MaxUint32, policy)
+	if !ok {
+		return 0, TimestampOutOfRangeError{
+			Time: t,
+			Min:  ToStandardizedTimeWithEpoch(0, epoch),
+			Max:  ToStandardizedTimeWithEpoch(math.MaxUint32, epoch),
+		}
+	}
+	return uint32(ts), nil
+}
+
+// AdjustTimestampMs converts a time.Time to a millisecond timestamp counted from 'epoch' applying 'policy'
+// to time out of timestamp range. Zero 'epoch' means DefaultEpoch.
+func AdjustTimestampMs(t time.Time, epoch time.Time, policy TimestampRangePolicy) (uint64, error) {
+	ts, ok := applyRangePolicy(t.UnixMilli()-epochSeconds(epoch)*1000, MaxTimestampMs, policy)
+	if !ok {
+		return 0, TimestampOutOfRangeError{
+			Time: t,
+			Min:  ToStandardizedTimeMsWithEpoch(0, epoch),
+			Max:  ToStandardizedTimeMsWithEpoch(MaxTimestampMs, epoch),
+		}
+	}
+	return uint64(ts), nil
+}
+
+// applyRangePolicy returns timestamp in range [0, max], 'max' must be 2^n-1.
+func applyRangePolicy(ts int64, max int64, policy TimestampRangePolicy) (int64, bool) {
+	if ts >= 0 && ts <= max {
+		return ts, true
+	}
+	switch policy {
+	case TimestampRangeClamp:
+		if ts < 0 {
+			return 0, true
+		}
+		return max, true
+	case TimestampRangeWrap:
+		return ts & max, true
+	}
+	return 0, false
+}
+
+func epochSeconds(epoch time.Time) int64 {
+	if epoch.IsZero() {
+		return epochStamp
+	}
+	return epoch.Unix()
+}
@@ -1,6 +1,7 @@
 package pkg
 
 import (
+	"errors"
 	"testing"
 	"time"
 )
@@ -62,3 +63,92 @@ func TestToStandardizedTime(t *testing.T) {
 		})
 	}
 }
+
+func TestTimestampMs(t *testing.T) {
+	cases := map[string]struct {
+		time time.Time
+		ts   uint64
+	}{
+		"min time":           {time.Date(2014, 5, 13, 16, 53, 20, 0, time.UTC), 0},
+		"1ms":                {time.Date(2014, 5, 13, 16, 53, 20, 1000000, time.UTC), 1},
+		"0x12345678 seconds": {time.Date(2024, 1, 16, 15, 44, 56, 789000000, time.UTC), 0x12345678*1000 + 789},
+		"max time":           {time.Date(10933, 12, 13, 22, 25, 10, 655000000, time.UTC), MaxTimestampMs},
+	}
+	for _, key := range SortKeys(cases) {
+		tc := cases[key]
+		t.Run(key, func(t *testing.T) {
+			if got := ToAdjustedTimestampMs(tc.time); got != tc.ts {
+				t.Errorf("ToAdjustedTimestampMs(%v) =\n%d, want\n%d", tc.time, got, tc.ts)
+			}
+			if got := ToStandardizedTimeMs(tc.ts); !got.Equal(tc.time) {
+				t.Errorf("ToStandardizedTimeMs(%d) =\n%v, want\n%v", tc.ts, got, tc.time)
+			}
+		})
+	}
+}
+
+func TestAdjustTimestamp(t *testing.T) {
+	before := time.Date(2014, 5, 13, 16, 53, 19, 0, time.UTC)
+	after := time.Date(2150, 6, 19, 23, 21, 36, 0, time.UTC)
+	cases := map[string]struct {
+		time   time.Time
+		policy TimestampRangePolicy
+		ts     uint32
+		err    bool
+	}{
+		"in range error": {time.Date(2024, 1, 16, 15, 44, 56, 0, time.UTC), TimestampRangeError, 0x12345678, false},
+		"in range clamp": {time.Date(2024, 1, 16, 15, 44, 56, 0, time.UTC), TimestampRangeClamp, 0x12345678, false},
+		"in range wrap":  {time.Date(2024, 1, 16, 15, 44, 56, 0, time.UTC), TimestampRangeWrap, 0x12345678, false},
+		"before error":   {before, TimestampRangeError, 0, true},
+		"before clamp":   {before, TimestampRangeClamp, 0, false},
+		"before wrap":    {before, TimestampRangeWrap, 0xFFFFFFFF, false},
+		"after error":    {after, TimestampRangeError, 0, true},
+		"after clamp":    {after, TimestampRangeClamp, 0xFFFFFFFF, false},
+		"after wrap":     {after, TimestampRangeWrap, 0, false},
+	}
+	for _, key := range SortKeys(cases) {
+		tc := cases[key]
+		t.Run(key, func(t *testing.T) {
+			got, err := AdjustTimestamp(tc.time, time.Time{}, tc.policy)
+			var rangeErr TimestampOutOfRangeError
+			if tc.err != errors.As(err, &rangeErr) {
+				t.Errorf("AdjustTimestamp(%s, %d) error = %v, want error %t", tc.time, tc.policy, err, tc.err)
+			}
+			if got != tc.ts {
+				t.Errorf("AdjustTimestamp(%s, %d) = %d, want %d", tc.time, tc.policy, got, tc.ts)
+			}
+		})
+	}
+}
+
+func TestAdjustTimestampMs(t *testing.T) {
+	before := time.Date(2014, 5, 13, 16, 53, 19, 999000000, time.UTC)
+	cases := map[string]struct {
+		time   time.Time
+		policy TimestampRangePolicy
+		ts     uint64
+		err    bool
+	}{
+		"in range":     {time.Date(2014, 5, 13, 16, 53, 20, 1000000, time.UTC), TimestampRangeError, 1, false},
+		"before error": {before, TimestampRangeError, 0, true},
+		"before clamp": {before, TimestampRangeClamp, 0, false},
+		"before wrap":  {before, TimestampRangeWrap, MaxTimestampMs, false},
+	}
+	for _, key := range SortKeys(cases) {
+		tc := cases[key]
+		t.Run(key, func(t *testing.T) {
+			got, err := AdjustTimestampMs(tc.time, time.Time{}, tc.policy)
+			var rangeErr TimestampOutOfRangeError
+			if tc.err != errors.As(err, &rangeErr) {
+				t.Errorf("AdjustTimestampMs(%s, %d) error = %v, want error %t", tc.time, tc.policy, err, tc.err)
+			}
+			if got != tc.ts {
+				t.Errorf("AdjustTimestampMs(%s, %d) = %d, want %d", tc.time, tc.policy, got, tc.ts)
+			}
+		})
+	}
+	var rangeErr TimestampOutOfRangeError
+	if _, err := NewCcId96MsWithFingerprint(MaxTimestampMs+1, nil, make([]byte, 6)); !errors.As(err, &rangeErr) {
+		t.Errorf("NewCcId96MsWithFingerprint(MaxTimestampMs+1) error = %v, want TimestampOutOfRangeError", err)
+	}
+}
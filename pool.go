@@ -0,0 +1,162 @@
+package ccid_go
+
+import (
+	"context"
+	"errors"
+	p "github.com/Pencroff/ccid_go/pkg"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// MaxPoolShards is the maximum number of shards in CcIdGenPool, shard index takes one fingerprint byte.
+const MaxPoolShards = 256
+
+// CcIdGenPool is a thread-safe CcIdGen without a global lock.
+// It keeps a CcIdGenImplementation per shard and every call is served by a single shard:
+// a caller takes the first free shard starting from a round-robin position and waits only if all shards are busy.
+//
+// Uniqueness across shards is guaranteed by the fingerprint, the pool appends shard index as the last fingerprint byte.
+// Ordering guarantees:
+//   - CcIds of the same shard are monotonic if the shard has a monotonic strategy, like CcIdGenImplementationLocked
+//   - CcIds are globally time-ordered only at timestamp granularity, seconds or milliseconds:
+//     a CcId with a later timestamp is greater than any CcId with an earlier timestamp
+//   - CcIds of different shards with the same timestamp are ordered by fingerprint, not by generation order
+//   - CcIds of a NextN or Fill batch come from one shard and keep its ordering
+type CcIdGenPool struct {
+	shards  []poolShard
+	counter atomic.Uint64
+}
+
+type poolShard struct {
+	m   sync.Mutex
+	gen *CcIdGenImplementation
+	// padding keeps shards on separate cache lines
+	_ [48]byte
+}
+
+// NewCcIdGenPool creates a CcIdGenPool with the given number of shards.
+// 'shards' must be from 1 to MaxPoolShards.
+// 'shardOptions' returns options of the shard generator, it's called once per shard index.
+// Shards must have the same size, precision and epoch. Readers, strategies and state stores are not thread-safe,
+// so every shard requires its own reader, strategy and store, e.g. e.NewHybridRandReader per shard.
+// Metrics can be shared, e.g. Counters.
+// The shard index is appended to the fingerprint, so the fingerprint must be at least one byte shorter than maximum.
+func NewCcIdGenPool(shards int, shardOptions func(shard int) ([]Option, error)) (*CcIdGenPool, error) {
+	if shards < 1 || shards > MaxPoolShards {
+		return nil, InvalidOptionError("shards")
+	}
+	pool := &CcIdGenPool{shards: make([]poolShard, shards)}
+	var first generatorConfig
+	for i := range pool.shards {
+		opts, err := shardOptions(i)
+		if err != nil {
+			return nil, err
+		}
+		// the shard index is appended to the resolved fingerprint, e.g. of WithFingerprintProvider
+		cfg, err := applyOptions(opts)
+		if err != nil {
+			return nil, err
+		}
+		cfg.appendShard(i)
+		err = cfg.validate()
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			first = cfg
+		} else if cfg.size != first.size || cfg.millisecond != first.millisecond || !cfg.epoch.Equal(first.epoch) {
+			return nil, InvalidOptionError("shardOptions")
+		}
+		gen := newGenerator(cfg)
+		err = gen.restore()
+		if err != nil {
+			return nil, err
+		}
+		pool.shards[i].gen = gen
+	}
+	return pool, nil
+}
+
+// appendShard appends the shard index to the fingerprint.
+func (cfg *generatorConfig) appendShard(shard int) {
+	fingerprint := make([]byte, len(cfg.fingerprint), len(cfg.fingerprint)+1)
+	copy(fingerprint, cfg.fingerprint)
+	cfg.fingerprint = append(fingerprint, byte(shard))
+}
+
+// Shards returns the number of shards in the pool.
+func (g *CcIdGenPool) Shards() int {
+	return len(g.shards)
+}
+
+func (g *CcIdGenPool) Next() (p.CcId, error) {
+	s := g.acquire()
+	defer s.m.Unlock()
+	return s.gen.Next()
+}
+
+// NextContext holds the shard while waiting, other shards keep serving callers.
+func (g *CcIdGenPool) NextContext(ctx context.Context) (p.CcId, error) {
+	s := g.acquire()
+	defer s.m.Unlock()
+	return s.gen.NextContext(ctx)
+}
+
+func (g *CcIdGenPool) NextWithTime(t time.Time) (p.CcId, error) {
+	s := g.acquire()
+	defer s.m.Unlock()
+	return s.gen.NextWithTime(t)
+}
+
+func (g *CcIdGenPool) NextN(n int) ([]p.CcId, error) {
+	s := g.acquire()
+	defer s.m.Unlock()
+	return s.gen.NextN(n)
+}
+
+func (g *CcIdGenPool) Fill(dst []p.CcId) error {
+	s := g.acquire()
+	defer s.m.Unlock()
+	return s.gen.Fill(dst)
+}
+
+// Drift returns the largest drift of the shards.
+func (g *CcIdGenPool) Drift() time.Duration {
+	var drift time.Duration
+	for i := range g.shards {
+		s := &g.shards[i]
+		s.m.Lock()
+		drift = max(drift, s.gen.Drift())
+		s.m.Unlock()
+	}
+	return drift
+}
+
+// Close saves state of every shard with a StateStore, see CcIdGenImplementation.Close.
+func (g *CcIdGenPool) Close() error {
+	var errs []error
+	for i := range g.shards {
+		s := &g.shards[i]
+		s.m.Lock()
+		errs = append(errs, s.gen.Close())
+		s.m.Unlock()
+	}
+	return errors.Join(errs...)
+}
+
+// acquire locks and returns the first free shard starting from the round-robin position,
+// it waits for the shard at that position if all shards are busy.
+func (g *CcIdGenPool) acquire() *poolShard {
+	n := uint64(len(g.shards))
+	start := g.counter.Add(1)
+	for i := uint64(0); i < n; i++ {
+		s := &g.shards[(start+i)%n]
+		if s.m.TryLock() {
+			return s
+		}
+	}
+	s := &g.shards[start%n]
+	s.m.Lock()
+	return s
+}
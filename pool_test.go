@@ -0,0 +1,180 @@
+package ccid_go
+
+import (
+	"errors"
+	e "github.com/Pencroff/ccid_go/extras"
+	"github.com/Pencroff/ccid_go/fingerprint"
+	p "github.com/Pencroff/ccid_go/pkg"
+	"sync"
+	"testing"
+	"time"
+)
+
+func monotonicShardOptions(size byte, fingerprint []byte) func(shard int) ([]Option, error) {
+	return func(shard int) ([]Option, error) {
+		r, err := e.NewHybridRandReader()
+		if err != nil {
+			return nil, err
+		}
+		return []Option{WithSize(size), WithFingerprint(fingerprint), WithReader(r),
+			WithStrategy(p.NewFiftyPercentMonotonicStrategy(r))}, nil
+	}
+}
+
+func TestCcIdGenPool(t *testing.T) {
+	const (
+		numRoutines = 64
+		numCycles   = 200
+	)
+	pool, err := NewCcIdGenPool(8, monotonicShardOptions(p.ByteSliceSize128, []byte{0xAB}))
+	if err != nil {
+		t.Fatalf("NewCcIdGenPool() error = %v", err)
+	}
+	if pool.Shards() != 8 {
+		t.Errorf("Shards() = %d, want 8", pool.Shards())
+	}
+	queue := make(chan p.CcId, numRoutines*numCycles)
+	var wg sync.WaitGroup
+	wg.Add(numRoutines)
+	for i := 0; i < numRoutines; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < numCycles; j++ {
+				id, err := pool.Next()
+				if err != nil {
+					t.Errorf("Next() error = %v", err)
+					return
+				}
+				queue <- id
+			}
+		}()
+	}
+	wg.Wait()
+	close(queue)
+	seen := map[string]bool{}
+	lastByShard := map[byte]p.CcId{}
+	for id := range queue {
+		key := string(id.Bytes())
+		if seen[key] {
+			t.Fatalf("duplicate CcId %x", id.Bytes())
+		}
+		seen[key] = true
+		fp := id.Fingerprint()
+		if len(fp) != 2 || fp[0] != 0xAB || fp[1] >= 8 {
+			t.Fatalf("Fingerprint() = %x, want ab0X", fp)
+		}
+		lastByShard[fp[1]] = id
+	}
+	if len(seen) != numRoutines*numCycles {
+		t.Errorf("unique CcIds = %d, want %d", len(seen), numRoutines*numCycles)
+	}
+	if len(lastByShard) < 2 {
+		t.Errorf("used shards = %d, want more than 1", len(lastByShard))
+	}
+}
+
+func TestCcIdGenPool_Monotonic(t *testing.T) {
+	pool, err := NewCcIdGenPool(1, monotonicShardOptions(p.ByteSliceSize96, nil))
+	if err != nil {
+		t.Fatalf("NewCcIdGenPool() error = %v", err)
+	}
+	mockTime := time.Date(2024, 1, 16, 15, 44, 56, 0, time.UTC)
+	prev, _ := pool.NextWithTime(mockTime)
+	ids, err := pool.NextN(100)
+	if err != nil {
+		t.Fatalf("NextN() error = %v", err)
+	}
+	for i, id := range ids {
+		if p.Compare(prev, id) >= 0 {
+			t.Errorf("CcId %d is not monotonic: %x >= %x", i, prev.Bytes(), id.Bytes())
+		}
+		prev = id
+	}
+	dst := make([]p.CcId, 10)
+	if err = pool.Fill(dst); err != nil || p.Compare(prev, dst[0]) >= 0 {
+		t.Errorf("Fill() = %x, %v, want greater than %x", dst[0].Bytes(), err, prev.Bytes())
+	}
+}
+
+func TestCcIdGenPool_FingerprintProvider(t *testing.T) {
+	pool, err := NewCcIdGenPool(4, func(shard int) ([]Option, error) {
+		return []Option{WithSize(p.ByteSliceSize128), WithFingerprintProvider(fingerprint.String("svc"), 2),
+			WithReader(&mockReader{})}, nil
+	})
+	if err != nil {
+		t.Fatalf("NewCcIdGenPool() error = %v", err)
+	}
+	prefix, _ := fingerprint.String("svc").Fingerprint(2)
+	seen := map[string]bool{}
+	for i := range pool.shards {
+		id, err := pool.shards[i].gen.Next()
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		fp := id.Fingerprint()
+		if len(fp) != 3 || !p.SliceEqual(fp[:2], prefix) || fp[2] != byte(i) || seen[string(fp)] {
+			t.Errorf("shard %d fingerprint = %x, want %x%02x", i, fp, prefix, i)
+		}
+		seen[string(fp)] = true
+	}
+}
+
+func TestNewCcIdGenPool_Error(t *testing.T) {
+	readerErr := errors.New("reader")
+	var fpErr p.InvalidFingerprintSizeError
+	var optionErr InvalidOptionError
+	cases := map[string]struct {
+		shards int
+		opts   func(shard int) ([]Option, error)
+		target any
+	}{
+		"no shards":            {0, monotonicShardOptions(p.ByteSliceSize96, nil), &optionErr},
+		"too many shards":      {MaxPoolShards + 1, monotonicShardOptions(p.ByteSliceSize96, nil), &optionErr},
+		"large fingerprint 64": {2, monotonicShardOptions(p.ByteSliceSize64, []byte{1}), &fpErr},
+		"large fingerprint":    {2, monotonicShardOptions(p.ByteSliceSize128, make([]byte, 5)), &fpErr},
+		"different sizes": {2, func(shard int) ([]Option, error) {
+			return []Option{WithSize(p.ByteSliceSize96 + byte(shard)*4), WithReader(&mockReader{})}, nil
+		}, &optionErr},
+	}
+	for _, key := range p.SortKeys(cases) {
+		c := cases[key]
+		t.Run(key, func(t *testing.T) {
+			pool, err := NewCcIdGenPool(c.shards, c.opts)
+			if !errors.As(err, c.target) || pool != nil {
+				t.Errorf("NewCcIdGenPool() = %v, %v, want %T", pool, err, c.target)
+			}
+		})
+	}
+	t.Run("options error", func(t *testing.T) {
+		pool, err := NewCcIdGenPool(2, func(shard int) ([]Option, error) {
+			return nil, readerErr
+		})
+		if !errors.Is(err, readerErr) || pool != nil {
+			t.Errorf("NewCcIdGenPool() = %v, %v, want %v", pool, err, readerErr)
+		}
+	})
+}
+
+func BenchmarkCcIdGenLocked_Parallel(b *testing.B) {
+	r, _ := e.NewHybridRandReaderWithSize(e.SIZE_32k)
+	gen, _ := NewMonotonicCcIdGen(p.ByteSliceSize128, r, p.NewFiftyPercentMonotonicStrategy(r))
+	lockedGen := NewCcIdGenLocked(gen)
+	b.SetParallelism(64)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, _ = lockedGen.Next()
+		}
+	})
+}
+
+func BenchmarkCcIdGenPool_Parallel(b *testing.B) {
+	pool, _ := NewCcIdGenPool(16, monotonicShardOptions(p.ByteSliceSize128, nil))
+	b.SetParallelism(64)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, _ = pool.Next()
+		}
+	})
+}
@@ -0,0 +1,118 @@
+package ccid_go
+
+import (
+	e "github.com/Pencroff/ccid_go/extras"
+	p "github.com/Pencroff/ccid_go/pkg"
+	"io"
+	"sync"
+	"time"
+)
+
+// NewSeededGenerator creates a deterministic CcId Generator for tests and debugging.
+// Random bytes come from e.NewSeededRandReader, so generators with the same 'seed' and clock readings
+// produce the same CcIds, e.g. with e.NewScriptedClock. The generator is monotonic with
+// p.FiftyPercentMonotonicStrategy on the seeded reader, 'opts' can override it, WithSize is required.
+func NewSeededGenerator(seed int64, clock p.Clock, opts ...Option) (CcIdGen, error) {
+	r := e.NewSeededRandReader(seed)
+	return NewGenerator(append([]Option{WithReader(r), WithStrategy(p.NewFiftyPercentMonotonicStrategy(r)),
+		WithClock(clock)}, opts...)...)
+}
+
+// Recording is a sequence of clock readings and random bytes consumed by a generator, see Recorder.
+// It can be stored as JSON and replayed in a test to regenerate the same CcIds.
+type Recording struct {
+	Times  []time.Time
+	Random []byte
+}
+
+// Recorder wraps the random reader and the clock of a live generator and records everything they return.
+// Use it for both WithReader and WithClock, and for the strategy reader:
+//
+//	rec := NewRecorder(r, p.RealClock{})
+//	gen, err := NewGenerator(WithSize(p.ByteSliceSize128), WithReader(rec),
+//		WithStrategy(p.NewFiftyPercentMonotonicStrategy(rec)), WithClock(rec))
+//
+// It's safe for concurrent use, but the recording reproduces CcIds only if the generator is called sequentially.
+type Recorder struct {
+	m         sync.Mutex
+	rd        io.Reader
+	clock     p.Clock
+	recording Recording
+}
+
+// NewRecorder creates a new Recorder of 'rd' and 'clock'.
+func NewRecorder(rd io.Reader, clock p.Clock) *Recorder {
+	return &Recorder{rd: rd, clock: clock}
+}
+
+// Read implements io.Reader interface, read bytes are recorded.
+func (r *Recorder) Read(b []byte) (int, error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	n, err := r.rd.Read(b)
+	r.recording.Random = append(r.recording.Random, b[:n]...)
+	return n, err
+}
+
+// Now implements p.Clock interface, the time is recorded.
+func (r *Recorder) Now() time.Time {
+	r.m.Lock()
+	defer r.m.Unlock()
+	t := r.clock.Now()
+	r.recording.Times = append(r.recording.Times, t)
+	return t
+}
+
+// Recording returns a copy of everything recorded so far.
+func (r *Recorder) Recording() Recording {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return Recording{
+		Times:  append([]time.Time(nil), r.recording.Times...),
+		Random: append([]byte(nil), r.recording.Random...),
+	}
+}
+
+// Replay returns a reader and a clock returning the recorded bytes and times in order.
+// Use them in place of the recorded reader and clock of a generator with the same options.
+// The reader returns io.EOF when the bytes are exhausted, the clock repeats the last time.
+func (rec Recording) Replay() (io.Reader, p.Clock) {
+	return &replayReader{random: rec.Random}, &replayClock{times: rec.Times}
+}
+
+type replayReader struct {
+	m      sync.Mutex
+	random []byte
+}
+
+func (r *replayReader) Read(b []byte) (int, error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	if len(b) == 0 {
+		return 0, nil
+	}
+	if len(r.random) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(b, r.random)
+	r.random = r.random[n:]
+	if n < len(b) {
+		return n, io.ErrUnexpectedEOF
+	}
+	return n, nil
+}
+
+type replayClock struct {
+	m     sync.Mutex
+	times []time.Time
+	last  time.Time
+}
+
+func (c *replayClock) Now() time.Time {
+	c.m.Lock()
+	defer c.m.Unlock()
+	if len(c.times) > 0 {
+		c.last, c.times = c.times[0], c.times[1:]
+	}
+	return c.last
+}
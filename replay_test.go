@@ -0,0 +1,91 @@
+package ccid_go
+
+import (
+	"encoding/json"
+	"errors"
+	e "github.com/Pencroff/ccid_go/extras"
+	p "github.com/Pencroff/ccid_go/pkg"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestNewSeededGenerator(t *testing.T) {
+	start := time.Date(2024, 1, 16, 15, 44, 56, 0, time.UTC)
+	newIds := func(seed int64, opts ...Option) []p.CcId {
+		clock := e.NewScriptedClock(start, 100*time.Millisecond, start.Add(time.Second), start)
+		gen, err := NewSeededGenerator(seed, clock, append([]Option{WithSize(p.ByteSliceSize96)}, opts...)...)
+		if err != nil {
+			t.Fatalf("NewSeededGenerator() error = %v", err)
+		}
+		ids := make([]p.CcId, 30)
+		for i := range ids {
+			ids[i], _ = gen.Next()
+		}
+		return ids
+	}
+	a, b, other := newIds(42), newIds(42), newIds(43)
+	for i := range a {
+		if !p.SliceEqual(a[i].Bytes(), b[i].Bytes()) {
+			t.Errorf("CcId %d = %x, want %x", i, b[i].Bytes(), a[i].Bytes())
+		}
+		if i > 0 && p.Compare(a[i-1], a[i]) >= 0 {
+			t.Errorf("CcId %d is not monotonic: %x >= %x", i, a[i-1].Bytes(), a[i].Bytes())
+		}
+	}
+	if p.SliceEqual(a[0].Bytes(), other[0].Bytes()) {
+		t.Errorf("CcId with another seed = %x, want different", other[0].Bytes())
+	}
+	nonMonotonic := newIds(42, WithStrategy(nil))
+	if p.SliceEqual(a[2].Bytes(), nonMonotonic[2].Bytes()) {
+		t.Errorf("non-monotonic CcId = %x, want different", nonMonotonic[2].Bytes())
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	r, _ := e.NewHybridRandReader()
+	rec := NewRecorder(r, p.RealClock{})
+	opts := []Option{WithSize(p.ByteSliceSize64), WithFingerprint([]byte{0x42})}
+	gen, _ := NewGenerator(append(opts, WithReader(rec), WithStrategy(p.NewFiftyPercentMonotonicStrategy(rec)),
+		WithClock(rec))...)
+	var ids []p.CcId
+	for i := 0; i < 50; i++ {
+		id, _ := gen.Next()
+		ids = append(ids, id)
+	}
+	batch, _ := gen.NextN(100)
+	ids = append(ids, batch...)
+
+	data, err := json.Marshal(rec.Recording())
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var recording Recording
+	if err = json.Unmarshal(data, &recording); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if len(recording.Times) != 51 {
+		t.Errorf("recorded times = %d, want 51", len(recording.Times))
+	}
+	rd, clock := recording.Replay()
+	replayed, _ := NewGenerator(append(opts, WithReader(rd), WithStrategy(p.NewFiftyPercentMonotonicStrategy(rd)),
+		WithClock(clock))...)
+	for i := 0; i < 50; i++ {
+		id, err := replayed.Next()
+		if err != nil || !p.SliceEqual(id.Bytes(), ids[i].Bytes()) {
+			t.Fatalf("replayed CcId %d = %x, %v, want %x", i, id.Bytes(), err, ids[i].Bytes())
+		}
+	}
+	batch, err = replayed.NextN(100)
+	for i, id := range batch {
+		if err != nil || !p.SliceEqual(id.Bytes(), ids[50+i].Bytes()) {
+			t.Fatalf("replayed CcId %d = %x, %v, want %x", 50+i, id.Bytes(), err, ids[50+i].Bytes())
+		}
+	}
+	if _, err = replayed.Next(); err == nil {
+		t.Errorf("Next() after replay error = nil, want error")
+	}
+	if _, err = rd.Read(make([]byte, 1)); !errors.Is(err, io.EOF) {
+		t.Errorf("Read() after replay error = %v, want %v", err, io.EOF)
+	}
+}
@@ -0,0 +1,156 @@
+package ccid_go
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	p "github.com/Pencroff/ccid_go/pkg"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// GeneratorState is a high-water mark of a monotonic generator, no CcId above it was generated.
+// Restored generator continues after it, so monotonicity survives process restarts.
+type GeneratorState struct {
+	Timestamp uint64
+	Payload   []byte
+}
+
+// StateStore persists GeneratorState of a monotonic generator, see WithStateStore.
+type StateStore interface {
+	// Load returns the saved state, 'ok' is false if nothing was saved yet.
+	Load() (state GeneratorState, ok bool, err error)
+	// Save persists the state, it must be durable when Save returns.
+	Save(state GeneratorState) error
+}
+
+// InvalidStateError is returned when a saved generator state is corrupted or doesn't match the generator.
+// The value describes the problem.
+type InvalidStateError string
+
+func (e InvalidStateError) Error() string {
+	return fmt.Sprintf("CCID: invalid generator state, %s", string(e))
+}
+
+const stateFileVersion = 1
+
+// FileStateStore is a StateStore keeping the state in a file.
+// The file is replaced atomically: the state is written to a temp file in the same directory,
+// synced to disk and renamed over the previous one.
+type FileStateStore struct {
+	path string
+}
+
+// NewFileStateStore creates a FileStateStore for the file at 'path', the directory must exist.
+func NewFileStateStore(path string) *FileStateStore {
+	return &FileStateStore{path: path}
+}
+
+// Load implements StateStore interface, missing file means no saved state.
+func (s *FileStateStore) Load() (GeneratorState, bool, error) {
+	b, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return GeneratorState{}, false, nil
+	}
+	if err != nil {
+		return GeneratorState{}, false, err
+	}
+	if len(b) < 9 || b[0] != stateFileVersion {
+		return GeneratorState{}, false, InvalidStateError("corrupted file " + s.path)
+	}
+	return GeneratorState{Timestamp: binary.BigEndian.Uint64(b[1:9]), Payload: b[9:]}, true, nil
+}
+
+// Save implements StateStore interface.
+func (s *FileStateStore) Save(state GeneratorState) error {
+	b := make([]byte, 9, 9+len(state.Payload))
+	b[0] = stateFileVersion
+	binary.BigEndian.PutUint64(b[1:9], state.Timestamp)
+	b = append(b, state.Payload...)
+
+	dir := filepath.Dir(s.path)
+	f, err := os.CreateTemp(dir, filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(b)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), s.path)
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+	// directory sync makes the rename durable, it's not supported on every platform
+	if d, err := os.Open(dir); err == nil {
+		_ = d.Sync()
+		_ = d.Close()
+	}
+	return nil
+}
+
+// restore sets the last CcId of the generator to the saved state.
+func (g *CcIdGenImplementation) restore() error {
+	if g.store == nil {
+		return nil
+	}
+	state, ok, err := g.store.Load()
+	if err != nil || !ok {
+		return err
+	}
+	if len(state.Payload) != len(g.lastPayload) {
+		return InvalidStateError(fmt.Sprintf("payload size %d bytes, required %d bytes", len(state.Payload), len(g.lastPayload)))
+	}
+	if state.Timestamp > g.maxTimestamp {
+		return InvalidStateError(fmt.Sprintf("timestamp %d out of range", state.Timestamp))
+	}
+	g.hasLast, g.hasMark = true, true
+	g.lastTimestamp, g.highWaterMark = state.Timestamp, state.Timestamp
+	copy(g.lastPayload, state.Payload)
+	// the saved state can be ahead of the clock, it's not a clock regression
+	g.clockTimestamp, _ = g.adjustTimestamp(g.clock.Now())
+	return nil
+}
+
+// reserve saves a new high-water mark if 'timestamp' reached the saved one.
+// The mark is placed a lease ahead, so the store is written at most once per lease.
+func (g *CcIdGenImplementation) reserve(timestamp uint64) error {
+	if g.store == nil || g.hasMark && g.isAfter(g.highWaterMark, timestamp) {
+		return nil
+	}
+	mark := timestamp + g.lease
+	if mark > g.maxTimestamp {
+		mark = g.maxTimestamp
+		if g.rangePolicy == p.TimestampRangeWrap {
+			mark = (timestamp + g.lease) & g.maxTimestamp
+		}
+	}
+	err := g.store.Save(GeneratorState{Timestamp: mark, Payload: make([]byte, len(g.lastPayload))})
+	if err != nil {
+		return err
+	}
+	g.highWaterMark, g.hasMark = mark, true
+	return nil
+}
+
+// Close saves the exact state of the generator to the store, it should be called on shutdown.
+// Restarted generator continues right after the last CcId instead of the lease high-water mark.
+// It does nothing without a store.
+func (g *CcIdGenImplementation) Close() error {
+	if g.store == nil || !g.hasLast {
+		return nil
+	}
+	err := g.store.Save(GeneratorState{Timestamp: g.lastTimestamp, Payload: append([]byte(nil), g.lastPayload...)})
+	if err != nil {
+		return err
+	}
+	g.highWaterMark, g.hasMark = g.lastTimestamp, true
+	return nil
+}
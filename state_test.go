@@ -0,0 +1,175 @@
+package ccid_go
+
+import (
+	"errors"
+	p "github.com/Pencroff/ccid_go/pkg"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// Unexported
+type mockStateStore struct {
+	State GeneratorState
+	Ok    bool
+	Saves int
+	Err   error
+}
+
+func (m *mockStateStore) Load() (GeneratorState, bool, error) {
+	return m.State, m.Ok, m.Err
+}
+
+func (m *mockStateStore) Save(state GeneratorState) error {
+	if m.Err != nil {
+		return m.Err
+	}
+	m.State, m.Ok = state, true
+	m.Saves += 1
+	return nil
+}
+
+func newStateGen(t *testing.T, store StateStore, c p.Clock, opts ...Option) CcIdGen {
+	r := &mockReader{}
+	gen, err := NewGenerator(append([]Option{WithSize(p.ByteSliceSize96), WithReader(r),
+		WithStrategy(p.NewFiftyPercentMonotonicStrategy(r)), WithClock(c), WithStateStore(store, 10*time.Second)}, opts...)...)
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	return gen
+}
+
+func TestFileStateStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ccid.state")
+	store := NewFileStateStore(path)
+	_, ok, err := store.Load()
+	if ok || err != nil {
+		t.Errorf("Load() = %v, %v, want no state", ok, err)
+	}
+	want := GeneratorState{Timestamp: 0x0123456789ab, Payload: []byte{1, 2, 3}}
+	for i := 0; i < 2; i++ {
+		if err = store.Save(want); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+	state, ok, err := store.Load()
+	if !ok || err != nil || state.Timestamp != want.Timestamp || !p.SliceEqual(state.Payload, want.Payload) {
+		t.Errorf("Load() = %v, %v, %v, want %v", state, ok, err, want)
+	}
+	files, _ := os.ReadDir(filepath.Dir(path))
+	if len(files) != 1 {
+		t.Errorf("directory has %d files, want only the state file", len(files))
+	}
+	_ = os.WriteFile(path, []byte{stateFileVersion, 1, 2}, 0o600)
+	var stateErr InvalidStateError
+	if _, _, err = store.Load(); !errors.As(err, &stateErr) {
+		t.Errorf("Load() error = %v, want InvalidStateError", err)
+	}
+	store = NewFileStateStore(filepath.Join(path, "missing", "ccid.state"))
+	if err = store.Save(want); err == nil {
+		t.Errorf("Save() error = nil, want error for missing directory")
+	}
+}
+
+func TestStateStore_Restart(t *testing.T) {
+	mockTime := time.Date(2024, 1, 16, 15, 44, 56, 0, time.UTC)
+	t.Run("close", func(t *testing.T) {
+		store := NewFileStateStore(filepath.Join(t.TempDir(), "ccid.state"))
+		c := &mockStaticClock{Val: mockTime}
+		gen := NewCcIdGenLocked(newStateGen(t, store, c))
+		ids, _ := gen.NextN(10)
+		if err := gen.Close(); err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+		c.Val = mockTime.Add(-time.Minute)
+		restarted := newStateGen(t, store, c)
+		id, err := restarted.Next()
+		last := ids[len(ids)-1]
+		if err != nil || p.Compare(last, id) >= 0 || id.Timestamp() != last.Timestamp() {
+			t.Errorf("Next() = %x, %v, want right after %x", id.Bytes(), err, last.Bytes())
+		}
+	})
+	t.Run("crash", func(t *testing.T) {
+		store := &mockStateStore{}
+		c := &mockStaticClock{Val: mockTime}
+		gen := newStateGen(t, store, c)
+		ids, _ := gen.NextN(10)
+		c.Val = mockTime.Add(9 * time.Second)
+		next, _ := gen.Next()
+		ids = append(ids, next)
+		if store.Saves != 1 || store.State.Timestamp != uint64(ids[0].Timestamp())+10 {
+			t.Errorf("store = %d saves, timestamp %d, want 1 save, timestamp %d", store.Saves, store.State.Timestamp, ids[0].Timestamp()+10)
+		}
+		restarted := newStateGen(t, store, &mockStaticClock{Val: mockTime})
+		id, err := restarted.Next()
+		for _, prev := range ids {
+			if err != nil || p.Compare(prev, id) >= 0 {
+				t.Errorf("Next() = %x, %v, want after %x", id.Bytes(), err, prev.Bytes())
+			}
+		}
+		if store.Saves != 2 || store.State.Timestamp != uint64(id.Timestamp())+10 {
+			t.Errorf("store = %d saves, timestamp %d, want 2 saves, timestamp %d", store.Saves, store.State.Timestamp, id.Timestamp()+10)
+		}
+	})
+	t.Run("crash max drift", func(t *testing.T) {
+		store := &mockStateStore{}
+		c := &mockStaticClock{Val: mockTime}
+		gen := newStateGen(t, store, c, WithMaxDrift(10*time.Second))
+		last, _ := gen.Next()
+		restarted := newStateGen(t, store, c, WithMaxDrift(10*time.Second))
+		for i := 0; i < 3; i++ {
+			id, err := restarted.Next()
+			if err != nil || p.Compare(last, id) >= 0 {
+				t.Fatalf("Next() = %x, %v, want after %x", id.Bytes(), err, last.Bytes())
+			}
+			last = id
+		}
+	})
+}
+
+func TestStateStore_Error(t *testing.T) {
+	storeErr := errors.New("store error")
+	r := &mockReader{}
+	s := p.NewFiftyPercentMonotonicStrategy(r)
+	var optionErr InvalidOptionError
+	var stateErr InvalidStateError
+	cases := map[string]struct {
+		opts   []Option
+		target any
+	}{
+		"no strategy": {[]Option{WithStateStore(&mockStateStore{}, time.Second)}, &optionErr},
+		"no lease":    {[]Option{WithStrategy(s), WithStateStore(&mockStateStore{}, 0)}, &optionErr},
+		"lease over max drift": {[]Option{WithStrategy(s), WithStateStore(&mockStateStore{}, time.Minute),
+			WithMaxDrift(10 * time.Second)}, &optionErr},
+		"payload size": {[]Option{WithStrategy(s), WithStateStore(&mockStateStore{Ok: true}, time.Second)},
+			&stateErr},
+		"timestamp": {[]Option{WithStrategy(s), WithStateStore(&mockStateStore{
+			State: GeneratorState{Timestamp: 1 << 32, Payload: make([]byte, 8)}, Ok: true}, time.Second)}, &stateErr},
+	}
+	for _, key := range p.SortKeys(cases) {
+		c := cases[key]
+		t.Run(key, func(t *testing.T) {
+			gen, err := NewGenerator(append([]Option{WithSize(p.ByteSliceSize96), WithReader(r)}, c.opts...)...)
+			if !errors.As(err, c.target) || gen != nil {
+				t.Errorf("NewGenerator() = %v, %v, want %T", gen, err, c.target)
+			}
+		})
+	}
+	t.Run("load", func(t *testing.T) {
+		gen, err := NewGenerator(WithSize(p.ByteSliceSize96), WithReader(r), WithStrategy(s),
+			WithStateStore(&mockStateStore{Err: storeErr}, time.Second))
+		if !errors.Is(err, storeErr) || gen != nil {
+			t.Errorf("NewGenerator() = %v, %v, want %v", gen, err, storeErr)
+		}
+	})
+	t.Run("save", func(t *testing.T) {
+		store := &mockStateStore{}
+		gen := newStateGen(t, store, &mockStaticClock{Val: time.Now()})
+		store.Err = storeErr
+		_, err := gen.NextWithTime(time.Now().Add(time.Minute))
+		if !errors.Is(err, storeErr) {
+			t.Errorf("NextWithTime() error = %v, want %v", err, storeErr)
+		}
+	})
+}
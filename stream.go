@@ -0,0 +1,42 @@
+package ccid_go
+
+import (
+	"context"
+	p "github.com/Pencroff/ccid_go/pkg"
+)
+
+// Stream generates CcIds by 'g' into a buffered channel until 'ctx' is done or the generator fails.
+// 'size' is the channel buffer size, CcIds are pre-generated by Fill in batches of this size.
+// The CcId channel is closed when the stream stops, then the error channel receives the reason:
+// the generator error or ctx.Err().
+// 'g' can be any CcIdGen, use NewCcIdGenLocked or CcIdGenPool to share the generator between goroutines.
+func Stream(ctx context.Context, g CcIdGen, size int) (<-chan p.CcId, <-chan error) {
+	ids := make(chan p.CcId, max(size, 0))
+	errs := make(chan error, 1)
+	go func() {
+		defer close(errs)
+		err := stream(ctx, g, ids, make([]p.CcId, max(size, 1)))
+		close(ids)
+		errs <- err
+	}()
+	return ids, errs
+}
+
+func stream(ctx context.Context, g CcIdGen, ids chan<- p.CcId, batch []p.CcId) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		err := g.Fill(batch)
+		if err != nil {
+			return err
+		}
+		for _, id := range batch {
+			select {
+			case ids <- id:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package ccid_go
+
+import (
+	"context"
+	"errors"
+	p "github.com/Pencroff/ccid_go/pkg"
+	"testing"
+	"time"
+)
+
+var errMockReader = errors.New("mock reader error")
+
+// Unexported
+type mockFailingReader struct {
+	Reads int // number of successful reads
+}
+
+func (m *mockFailingReader) Read(p []byte) (n int, err error) {
+	if m.Reads == 0 {
+		return 0, errMockReader
+	}
+	m.Reads -= 1
+	return len(p), nil
+}
+
+func TestStream(t *testing.T) {
+	mockTime := time.Date(2024, 1, 16, 15, 44, 56, 0, time.UTC)
+	r := &mockReader{}
+	gen, _ := NewGenerator(WithSize(p.ByteSliceSize96), WithReader(r), WithStrategy(p.NewFiftyPercentMonotonicStrategy(r)),
+		WithClock(&mockStaticClock{Val: mockTime}))
+	ctx, cancel := context.WithCancel(context.Background())
+	ids, errs := Stream(ctx, NewCcIdGenLocked(gen), 8)
+	prev := p.NilCcId96
+	for i := 0; i < 20; i++ {
+		id := <-ids
+		if p.Compare(prev, id) >= 0 {
+			t.Errorf("CcId %d is not monotonic: %x >= %x", i, prev.Bytes(), id.Bytes())
+		}
+		prev = id.(p.CcId96)
+	}
+	cancel()
+	for range ids {
+	}
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Errorf("Stream() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestStream_Error(t *testing.T) {
+	gen, _ := NewCcIdGen(p.ByteSliceSize96, &mockFailingReader{Reads: 2})
+	ids, errs := Stream(context.Background(), gen, 0)
+	count := 0
+	for range ids {
+		count++
+	}
+	if err := <-errs; !errors.Is(err, errMockReader) || count != 2 {
+		t.Errorf("Stream() = %d CcIds, %v, want 2, %v", count, err, errMockReader)
+	}
+}